@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what AsyncWriter does when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the logging call wait for space in the buffer.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued entry to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the entry being logged.
+	OverflowDropNewest
+)
+
+type asyncEntry struct {
+	w    io.Writer
+	line []byte
+}
+
+// AsyncWriter queues formatted entries in a bounded buffer drained by a background goroutine.
+// Entries written after Close are written synchronously so that nothing is lost during shutdown.
+type AsyncWriter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []asyncEntry
+	size    int
+	policy  OverflowPolicy
+	pending int
+	closed  bool
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter starts a writer holding up to size entries. A size below 1 is treated as 1.
+func NewAsyncWriter(size int, policy OverflowPolicy) *AsyncWriter {
+	if size < 1 {
+		size = 1
+	}
+	a := &AsyncWriter{
+		queue:  make([]asyncEntry, 0, size),
+		size:   size,
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	a.cond = sync.NewCond(&a.mu)
+	go a.run()
+	return a
+}
+
+// Enqueue queues line for w, applying the overflow policy when the buffer is full.
+func (a *AsyncWriter) Enqueue(w io.Writer, line []byte) {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		_, _ = w.Write(line)
+		return
+	}
+	for len(a.queue) >= a.size {
+		switch a.policy {
+		case OverflowDropNewest:
+			a.dropped.Add(1)
+			a.mu.Unlock()
+			return
+		case OverflowDropOldest:
+			a.queue = append(a.queue[:0], a.queue[1:]...)
+			a.dropped.Add(1)
+		default:
+			a.cond.Wait()
+			if a.closed {
+				a.mu.Unlock()
+				_, _ = w.Write(line)
+				return
+			}
+		}
+	}
+	a.queue = append(a.queue, asyncEntry{w, line})
+	a.cond.Broadcast()
+	a.mu.Unlock()
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+	batch := make([]asyncEntry, 0, a.size)
+	for {
+		a.mu.Lock()
+		for len(a.queue) == 0 && !a.closed {
+			a.cond.Wait()
+		}
+		if len(a.queue) == 0 && a.closed {
+			a.mu.Unlock()
+			return
+		}
+		batch = append(batch[:0], a.queue...)
+		a.queue = a.queue[:0]
+		a.pending = len(batch)
+		a.cond.Broadcast()
+		a.mu.Unlock()
+
+		for _, e := range batch {
+			_, _ = e.w.Write(e.line)
+		}
+
+		a.mu.Lock()
+		a.pending = 0
+		a.cond.Broadcast()
+		a.mu.Unlock()
+	}
+}
+
+// Flush blocks until every entry queued so far has been written.
+func (a *AsyncWriter) Flush() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for len(a.queue) > 0 || a.pending > 0 {
+		a.cond.Wait()
+	}
+}
+
+// Close writes the remaining entries and stops the background goroutine. It is safe to call more than once.
+func (a *AsyncWriter) Close() {
+	a.mu.Lock()
+	a.closed = true
+	a.cond.Broadcast()
+	a.mu.Unlock()
+	<-a.done
+}
+
+// Dropped returns the number of entries discarded by the overflow policy.
+func (a *AsyncWriter) Dropped() uint64 {
+	return a.dropped.Load()
+}
@@ -0,0 +1,199 @@
+package internal_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+// gatedWriter blocks every write until the gate is opened.
+type gatedWriter struct {
+	gate    chan struct{}
+	started chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{gate: make(chan struct{}), started: make(chan struct{})}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	g.once.Do(func() { close(g.started) })
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+func TestAsyncWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	a := internal.NewAsyncWriter(16, internal.OverflowBlock)
+	defer a.Close()
+
+	for range 100 {
+		a.Enqueue(&buf, []byte("line\n"))
+	}
+	a.Flush()
+
+	assert.Equal(t, 100, strings.Count(buf.String(), "line\n"))
+	assert.Equal(t, uint64(0), a.Dropped())
+}
+
+func TestAsyncWriterDropNewest(t *testing.T) {
+	w := newGatedWriter()
+	a := internal.NewAsyncWriter(2, internal.OverflowDropNewest)
+
+	a.Enqueue(w, []byte("in-flight\n"))
+	<-w.started
+	a.Enqueue(w, []byte("queued-1\n"))
+	a.Enqueue(w, []byte("queued-2\n"))
+	a.Enqueue(w, []byte("dropped\n"))
+
+	close(w.gate)
+	a.Close()
+
+	assert.Equal(t, "in-flight\nqueued-1\nqueued-2\n", w.String())
+	assert.Equal(t, uint64(1), a.Dropped())
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+	w := newGatedWriter()
+	a := internal.NewAsyncWriter(2, internal.OverflowDropOldest)
+
+	a.Enqueue(w, []byte("in-flight\n"))
+	<-w.started
+	a.Enqueue(w, []byte("dropped\n"))
+	a.Enqueue(w, []byte("queued-1\n"))
+	a.Enqueue(w, []byte("queued-2\n"))
+
+	close(w.gate)
+	a.Close()
+
+	assert.Equal(t, "in-flight\nqueued-1\nqueued-2\n", w.String())
+	assert.Equal(t, uint64(1), a.Dropped())
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	w := newGatedWriter()
+	a := internal.NewAsyncWriter(1, internal.OverflowBlock)
+
+	a.Enqueue(w, []byte("in-flight\n"))
+	<-w.started
+	a.Enqueue(w, []byte("queued\n"))
+
+	blocked := make(chan struct{})
+	go func() {
+		a.Enqueue(w, []byte("waited\n"))
+		close(blocked)
+	}()
+
+	select {
+	case <-blocked:
+		t.Fatal("enqueue should block while the buffer is full")
+	default:
+	}
+
+	close(w.gate)
+	<-blocked
+	a.Close()
+
+	assert.Equal(t, "in-flight\nqueued\nwaited\n", w.String())
+	assert.Equal(t, uint64(0), a.Dropped())
+}
+
+func TestAsyncWriterClose(t *testing.T) {
+	var buf bytes.Buffer
+	a := internal.NewAsyncWriter(0, internal.OverflowBlock)
+
+	a.Enqueue(&buf, []byte("before\n"))
+	a.Close()
+	a.Close()
+	a.Enqueue(&buf, []byte("after\n"))
+	a.Flush()
+
+	assert.Equal(t, "before\nafter\n", buf.String())
+}
+
+func TestSimpleLoggerAsync(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &internal.SimpleLogger{
+		Namespace: "TEST",
+		Writer:    &buf,
+		Async:     internal.NewAsyncWriter(8, internal.OverflowBlock),
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				logger.With("k", "v").Info("async entry")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, logger.Flush())
+
+	assert.Equal(t, 100, strings.Count(buf.String(), "[TEST INFO] async entry k=v\n"))
+	assert.NoError(t, logger.Close())
+}
+
+func TestSimpleLoggerAsyncFatalFlushes(t *testing.T) {
+	w := newGatedWriter()
+	exited := false
+	logger := &internal.SimpleLogger{
+		Namespace: "TEST",
+		Writer:    w,
+		Async:     internal.NewAsyncWriter(8, internal.OverflowBlock),
+		ExitFunc:  func(int) { exited = true },
+	}
+	close(w.gate)
+
+	logger.Info("before fatal")
+	logger.Fatal("fatal")
+
+	assert.True(t, exited)
+	assert.Contains(t, w.String(), "before fatal")
+	assert.Contains(t, w.String(), "[TEST FATAL] fatal")
+}
+
+func TestSimpleLoggerAsyncDropped(t *testing.T) {
+	w := newGatedWriter()
+	logger := &internal.SimpleLogger{
+		Namespace: "TEST",
+		Writer:    w,
+		Async:     internal.NewAsyncWriter(1, internal.OverflowDropNewest),
+	}
+
+	logger.Info("in-flight")
+	<-w.started
+	logger.Info("queued")
+	logger.With("k", "v").Info("dropped")
+	logger.Named("child").Info("dropped")
+
+	close(w.gate)
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, uint64(2), logger.Dropped())
+	assert.NotContains(t, w.String(), "dropped")
+}
+
+func TestSimpleLoggerFlushWithoutAsync(t *testing.T) {
+	logger := &internal.SimpleLogger{Namespace: "TEST"}
+
+	assert.NoError(t, logger.Flush())
+	assert.NoError(t, logger.Close())
+	assert.Zero(t, logger.Dropped())
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+const maxStackDepth = 64
+
+var internalPkg = reflect.TypeFor[SimpleLogger]().PkgPath()
+
+// callerFrames returns the stack starting at the first frame outside the logging machinery.
+func callerFrames() []runtime.Frame {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var result []runtime.Frame
+	skipping := true
+	for {
+		frame, more := frames.Next()
+		if skipping && isLoggingFrame(frame) {
+			if !more {
+				break
+			}
+			continue
+		}
+		skipping = false
+		result = append(result, frame)
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// loggingFuncs are the function name prefixes of the logging machinery, whose frames are
+// skipped when locating the caller.
+var loggingFuncs = []string{internalPkg + ".", "log/slog."}
+
+// SkipCallerFuncs marks the methods of wrapper types, given as receivers such as
+// "example.com/pkg.(*teeLogger)", as logging machinery so that the reported caller is the code
+// calling the wrapper. Closures inside the methods are covered too. It must be called during
+// package initialization.
+func SkipCallerFuncs(receivers ...string) {
+	for _, receiver := range receivers {
+		loggingFuncs = append(loggingFuncs, receiver+".")
+	}
+}
+
+// isLoggingFrame reports whether frame belongs to this package, log/slog, or a wrapper
+// registered with SkipCallerFuncs.
+func isLoggingFrame(frame runtime.Frame) bool {
+	for _, prefix := range loggingFuncs {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortCaller formats a frame as "dir/file.go:line".
+func shortCaller(frame runtime.Frame) string {
+	dir, file := filepath.Split(frame.File)
+	return filepath.Join(filepath.Base(dir), file) + ":" + strconv.Itoa(frame.Line)
+}
+
+func formatStack(frames []runtime.Frame) string {
+	var sb strings.Builder
+	for i, frame := range frames {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+	}
+	return sb.String()
+}
+
+// errorStack returns the trace carried by the first ungerr.UnknownError found among args
+// and field values, or "" when there is none.
+func errorStack(args []any, fields []any) string {
+	for _, v := range args {
+		if stack := unknownErrorStack(v); stack != "" {
+			return stack
+		}
+	}
+	for _, f := range normalizeFields(fields) {
+		if stack := unknownErrorStack(f.value); stack != "" {
+			return stack
+		}
+	}
+	return ""
+}
+
+func unknownErrorStack(v any) string {
+	err, ok := v.(error)
+	if !ok {
+		return ""
+	}
+	var unknownErr *ungerr.UnknownError
+	if !errors.As(err, &unknownErr) || unknownErr == nil {
+		return ""
+	}
+	return unknownErr.Error()
+}
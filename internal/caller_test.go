@@ -0,0 +1,111 @@
+package internal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/itsLeonB/ungerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleLoggerCaller(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddCaller: true}
+
+		logger.Info("info")
+		logger.Infof("infof %d", 1)
+		logger.Infow("infow", "k", "v")
+		logger.Print("print")
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 4)
+		for _, line := range lines {
+			assert.Contains(t, line, "caller=internal/caller_test.go:")
+			assert.Contains(t, line, "func=github.com/itsLeonB/ezutil/v2/internal_test.TestSimpleLoggerCaller.func1")
+		}
+		assert.Contains(t, lines[2], "infow k=v caller=")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddCaller: true, Encoding: internal.EncodingJSON}
+
+		logger.Warn("warn")
+
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Contains(t, decoded["caller"], "internal/caller_test.go:")
+		assert.Equal(t, "github.com/itsLeonB/ezutil/v2/internal_test.TestSimpleLoggerCaller.func2", decoded["func"])
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf}
+
+		logger.Info("info")
+
+		assert.NotContains(t, buf.String(), "caller=")
+	})
+}
+
+func TestSimpleLoggerStacktrace(t *testing.T) {
+	t.Run("only at or above the stacktrace level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddStacktrace: true, StacktraceLevel: 3}
+
+		logger.Warn("warn")
+		assert.Equal(t, "", strings.SplitN(buf.String(), "\n", 2)[1])
+
+		buf.Reset()
+		logger.Errorf("error %d", 1)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Greater(t, len(lines), 2)
+		assert.Equal(t, "\tgithub.com/itsLeonB/ezutil/v2/internal_test.TestSimpleLoggerStacktrace.func1", lines[1])
+		assert.Contains(t, lines[2], "caller_test.go:")
+	})
+
+	t.Run("json stacktrace field", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddStacktrace: true, StacktraceLevel: 3, Encoding: internal.EncodingJSON}
+
+		logger.Error("error")
+
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Contains(t, decoded["stacktrace"], "TestSimpleLoggerStacktrace")
+	})
+
+	t.Run("uses the trace of an ungerr error argument", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddStacktrace: true, StacktraceLevel: 3}
+		err := fmt.Errorf("handler: %w", ungerr.Wrap(fmt.Errorf("db down"), "query failed"))
+
+		logger.Error("request failed:", err)
+
+		output := buf.String()
+		assert.Contains(t, output, "\twrapped error: query failed\n\t\tat github.com/itsLeonB/ezutil/v2/internal_test.TestSimpleLoggerStacktrace.func3")
+		assert.NotContains(t, output, "testing.tRunner")
+	})
+
+	t.Run("uses the trace of an ungerr error field", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddStacktrace: true, StacktraceLevel: 3}
+
+		logger.Errorw("request failed", "err", ungerr.Unknown("boom"))
+
+		assert.Contains(t, buf.String(), "\tboom\n\t\tat github.com/itsLeonB/ezutil/v2/internal_test.TestSimpleLoggerStacktrace.func4")
+	})
+
+	t.Run("falls back to the call stack for a typed nil ungerr error", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, AddStacktrace: true, StacktraceLevel: 3}
+
+		assert.NotPanics(t, func() { logger.Error("m", (*ungerr.UnknownError)(nil)) })
+		assert.Contains(t, buf.String(), "TestSimpleLoggerStacktrace")
+	})
+}
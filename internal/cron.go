@@ -0,0 +1,213 @@
+package internal
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of month, month
+// and day of week. Each field is a bit set of the values it matches.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record a day field starting with "*". As in standard cron, when both
+	// day fields are restricted a time matches if either of them does.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as Sunday; it is folded into 0 after parsing.
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a five-field cron expression or one of the descriptors @yearly, @annually,
+// @monthly, @weekly, @daily, @midnight and @hourly. Fields accept "*", values, ranges "a-b",
+// steps "*/n" and "a-b/n", comma-separated lists, and month and weekday names.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if spec, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = spec
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, ungerr.Unknownf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var (
+		s   CronSchedule
+		err error
+	)
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	s.dowAny = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+	return &s, nil
+}
+
+func (f cronField) parse(spec string) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(spec, ",") {
+		bitsForPart, err := f.parsePart(part)
+		if err != nil {
+			return 0, err
+		}
+		set |= bitsForPart
+	}
+	return set, nil
+}
+
+func (f cronField) parsePart(part string) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepPart)
+		if err != nil || n < 1 {
+			return 0, ungerr.Unknownf("invalid step %q in cron %s field", stepPart, f.name)
+		}
+		step = n
+	}
+
+	lo, hi := f.min, f.max
+	switch {
+	case rangePart == "*" || rangePart == "?":
+	case strings.Contains(rangePart, "-"):
+		from, to, _ := strings.Cut(rangePart, "-")
+		var err error
+		if lo, err = f.value(from); err != nil {
+			return 0, err
+		}
+		if hi, err = f.value(to); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, ungerr.Unknownf("invalid range %q in cron %s field", rangePart, f.name)
+		}
+	default:
+		v, err := f.value(rangePart)
+		if err != nil {
+			return 0, err
+		}
+		lo = v
+		if !hasStep {
+			hi = v
+		}
+	}
+
+	var set uint64
+	for v := lo; v <= hi; v += step {
+		set |= 1 << v
+	}
+	return set, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, ungerr.Unknownf("invalid value %q in cron %s field, expected %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// maxCronSearch bounds Next for expressions that never match, such as "0 0 30 2 *".
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first matching time strictly after t, in t's location, or the zero time
+// if there is none within five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxCronSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Duration(s.minutesUntilMatch(t.Minute())) * time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// minutesUntilMatch returns how far the next matching minute is from minute, or the distance
+// to the next hour when no later minute in this hour matches.
+func (s *CronSchedule) minutesUntilMatch(minute int) int {
+	later := s.minute >> uint(minute)
+	if later == 0 {
+		return 60 - minute
+	}
+	return bits.TrailingZeros64(later)
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
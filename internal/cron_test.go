@@ -0,0 +1,90 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2024-03-15 is a Friday.
+	from := time.Date(2024, 3, 15, 10, 17, 42, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2024, 3, 15, 11, 5, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2024, 3, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,20 jan,jun *", time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 0 1 * sat", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"10-20/5 10 * * *", time.Date(2024, 3, 15, 10, 20, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := internal.ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestCronScheduleNext_Location(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	schedule, err := internal.ParseCron("0 9 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2024, 3, 15, 3, 0, 0, 0, time.UTC).In(jakarta))
+
+	assert.Equal(t, time.Date(2024, 3, 16, 9, 0, 0, 0, jakarta), next)
+	assert.Equal(t, time.Date(2024, 3, 16, 2, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestCronScheduleNext_HalfHourOffset(t *testing.T) {
+	india := time.FixedZone("IST", 5*60*60+30*60)
+	schedule, err := internal.ParseCron("0 * * * *")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2024, 3, 15, 11, 0, 0, 0, india),
+		schedule.Next(time.Date(2024, 3, 15, 10, 40, 0, 0, india)))
+}
+
+func TestCronScheduleNext_NeverMatches(t *testing.T) {
+	schedule, err := internal.ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@reboot",
+	} {
+		_, err := internal.ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Encoding selects how SimpleLogger renders entries.
+type Encoding int
+
+const (
+	// EncodingText renders human-readable lines, optionally colored.
+	EncodingText Encoding = iota
+	// EncodingJSON renders one JSON object per line.
+	EncodingJSON
+)
+
+type entry struct {
+	time      time.Time
+	level     logLevel
+	namespace string
+	msg       string
+	fields    []any
+	caller    *runtime.Frame
+	stack     string
+}
+
+func encodeText(e entry, useColor bool) []byte {
+	var colorStart, colorReset string
+	if useColor {
+		colorStart = colors[e.level]
+		colorReset = "\033[0m"
+	}
+	fields := e.fields
+	if e.caller != nil {
+		fields = append(slices.Clip(fields), "caller", shortCaller(*e.caller), "func", e.caller.Function)
+	}
+	line := fmt.Sprintf("%s%s [%s %s] %s%s%s\n", e.time.Format("15:04:05.000"), colorStart, e.namespace, e.level, e.msg, formatFields(fields), colorReset)
+	if e.stack != "" {
+		line += "\t" + strings.ReplaceAll(e.stack, "\n", "\n\t") + "\n"
+	}
+	return []byte(line)
+}
+
+func encodeJSON(e entry) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"time":`)
+	writeJSONValue(&buf, e.time.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(&buf, string(e.level))
+	buf.WriteString(`,"namespace":`)
+	writeJSONValue(&buf, e.namespace)
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(&buf, e.msg)
+	if e.caller != nil {
+		buf.WriteString(`,"caller":`)
+		writeJSONValue(&buf, shortCaller(*e.caller))
+		buf.WriteString(`,"func":`)
+		writeJSONValue(&buf, e.caller.Function)
+	}
+	for _, f := range normalizeFields(e.fields) {
+		buf.WriteByte(',')
+		writeJSONValue(&buf, jsonFieldKey(f.key))
+		buf.WriteByte(':')
+		writeJSONValue(&buf, f.value)
+	}
+	if e.stack != "" {
+		buf.WriteString(`,"stacktrace":`)
+		writeJSONValue(&buf, e.stack)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// reservedJSONKeys are the keys encodeJSON writes itself.
+var reservedJSONKeys = map[string]bool{
+	"time":       true,
+	"level":      true,
+	"namespace":  true,
+	"msg":        true,
+	"caller":     true,
+	"func":       true,
+	"stacktrace": true,
+}
+
+// jsonFieldKey prefixes a field key that collides with a reserved key, such as "fields.msg",
+// so that log shippers keeping the last duplicate key do not lose the entry's own value.
+func jsonFieldKey(key string) string {
+	if reservedJSONKeys[key] {
+		return "fields." + key
+	}
+	return key
+}
+
+// writeJSONValue marshals v, falling back to its string form for values encoding/json rejects.
+// Errors are written as their message since most error types marshal to an empty object,
+// formatted through fmt so that a typed nil error is written as "<nil>" instead of panicking.
+func writeJSONValue(buf *bytes.Buffer, v any) {
+	if err, ok := v.(error); ok {
+		v = fmt.Sprint(err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	buf.Write(b)
+}
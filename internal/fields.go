@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const badKey = "!BADKEY"
+
+type field struct {
+	key   string
+	value any
+}
+
+// normalizeFields pairs alternating key-value arguments into fields.
+// A non-string key is kept as a value under the "!BADKEY" key, and a trailing key without a value
+// is reported the same way, mirroring log/slog.
+func normalizeFields(kv []any) []field {
+	fields := make([]field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); {
+		key, ok := kv[i].(string)
+		if !ok || i+1 >= len(kv) {
+			fields = append(fields, field{badKey, kv[i]})
+			i++
+			continue
+		}
+		fields = append(fields, field{key, kv[i+1]})
+		i += 2
+	}
+	return fields
+}
+
+// formatFields renders key-value pairs as " key=value" in insertion order.
+func formatFields(kv []any) string {
+	if len(kv) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, f := range normalizeFields(kv) {
+		sb.WriteByte(' ')
+		sb.WriteString(quoteIfNeeded(f.key))
+		sb.WriteByte('=')
+		sb.WriteString(quoteIfNeeded(formatValue(f.value)))
+	}
+	return sb.String()
+}
+
+// formatValue renders v as text. Errors and other values go through fmt, which prints a
+// typed nil whose method would dereference it as "<nil>" instead of panicking.
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func quoteIfNeeded(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NamespaceLevels holds minimum levels that override the logger level for namespace prefixes.
+// Reads are lock-free; writes replace the whole map.
+type NamespaceLevels struct {
+	mu     sync.Mutex
+	levels atomic.Pointer[map[string]int]
+}
+
+// Set overrides the minimum level for prefix and every namespace below it.
+func (n *NamespaceLevels) Set(prefix string, level int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	next := make(map[string]int)
+	if current := n.levels.Load(); current != nil {
+		maps.Copy(next, *current)
+	}
+	next[prefix] = level
+	n.levels.Store(&next)
+}
+
+// Delete removes the override for prefix.
+func (n *NamespaceLevels) Delete(prefix string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	current := n.levels.Load()
+	if current == nil {
+		return
+	}
+	next := maps.Clone(*current)
+	delete(next, prefix)
+	n.levels.Store(&next)
+}
+
+// Lookup returns the level of the longest prefix matching namespace.
+// A prefix matches the namespace itself and any namespace nested below it with a dot.
+func (n *NamespaceLevels) Lookup(namespace string) (int, bool) {
+	current := n.levels.Load()
+	if current == nil {
+		return 0, false
+	}
+	bestLen := -1
+	var level int
+	for prefix, l := range *current {
+		if len(prefix) <= bestLen || !hasNamespacePrefix(namespace, prefix) {
+			continue
+		}
+		bestLen = len(prefix)
+		level = l
+	}
+	return level, bestLen >= 0
+}
+
+func hasNamespacePrefix(namespace, prefix string) bool {
+	if prefix == "" || namespace == prefix {
+		return true
+	}
+	return strings.HasPrefix(namespace, prefix) && namespace[len(prefix)] == '.'
+}
+
+// JoinNamespace appends name to namespace with a dot, skipping empty parts.
+func JoinNamespace(namespace, name string) string {
+	switch {
+	case name == "":
+		return namespace
+	case namespace == "":
+		return name
+	default:
+		return namespace + "." + name
+	}
+}
@@ -0,0 +1,82 @@
+package internal_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceLevelsLookup(t *testing.T) {
+	var levels internal.NamespaceLevels
+
+	_, ok := levels.Lookup("app")
+	assert.False(t, ok, "empty overrides should not match")
+
+	levels.Set("app", 1)
+	levels.Set("app.billing", 0)
+	levels.Set("app.billing.stripe", 3)
+
+	tests := []struct {
+		namespace string
+		expected  int
+		found     bool
+	}{
+		{"app", 1, true},
+		{"app.api", 1, true},
+		{"app.billing", 0, true},
+		{"app.billing.invoices", 0, true},
+		{"app.billing.stripe", 3, true},
+		{"app.billing.stripe.webhooks", 3, true},
+		{"app.billingx", 1, true},
+		{"application", 0, false},
+		{"other", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			level, found := levels.Lookup(tt.namespace)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestNamespaceLevelsDelete(t *testing.T) {
+	var levels internal.NamespaceLevels
+	levels.Delete("missing")
+
+	levels.Set("app.billing", 0)
+	levels.Delete("app.billing")
+
+	_, ok := levels.Lookup("app.billing")
+	assert.False(t, ok)
+}
+
+func TestNamespaceLevelsConcurrentAccess(t *testing.T) {
+	var levels internal.NamespaceLevels
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			levels.Set("app", i%5)
+		}()
+		go func() {
+			defer wg.Done()
+			levels.Lookup("app.billing")
+		}()
+	}
+	wg.Wait()
+
+	_, ok := levels.Lookup("app")
+	assert.True(t, ok)
+}
+
+func TestJoinNamespace(t *testing.T) {
+	assert.Equal(t, "app.billing", internal.JoinNamespace("app", "billing"))
+	assert.Equal(t, "billing", internal.JoinNamespace("", "billing"))
+	assert.Equal(t, "app", internal.JoinNamespace("app", ""))
+}
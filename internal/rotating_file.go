@@ -0,0 +1,366 @@
+package internal
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.WriteCloser that writes to Filename and moves it aside to a
+// timestamped backup when it grows past MaxSize or a new day starts.
+type RotatingFile struct {
+	Filename   string
+	MaxSize    int64
+	Daily      bool
+	MaxBackups int
+	Compress   bool
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedOn time.Time
+	closed   bool
+
+	mill     chan string
+	millDone chan struct{}
+	signals  chan os.Signal
+	sigDone  chan struct{}
+}
+
+// Open opens or creates Filename for appending and starts the background goroutine that
+// compresses and prunes backups. When reopenOnSIGHUP is set, SIGHUP reopens the file so that
+// external tools such as logrotate can move it.
+func (r *RotatingFile) Open(reopenOnSIGHUP bool) error {
+	if r.Filename == "" {
+		return ungerr.Unknown("rotating file name cannot be empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Filename), 0o755); err != nil {
+		return ungerr.Wrap(err, "error creating log directory")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.openLocked(); err != nil {
+		return err
+	}
+
+	r.mill = make(chan string, 16)
+	r.millDone = make(chan struct{})
+	go r.runMill()
+
+	if reopenOnSIGHUP {
+		r.signals = make(chan os.Signal, 1)
+		r.sigDone = make(chan struct{})
+		signal.Notify(r.signals, syscall.SIGHUP)
+		go r.watchSignals()
+	}
+	return nil
+}
+
+func (r *RotatingFile) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r *RotatingFile) openLocked() error {
+	file, err := os.OpenFile(r.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return ungerr.Wrap(err, "error opening log file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return ungerr.Wrap(err, "error reading log file info")
+	}
+	r.file = file
+	r.size = info.Size()
+	r.openedOn = r.now()
+	if r.size > 0 {
+		// The file holds entries from an earlier run; date them by its last write so a
+		// restart on a later day still rotates them out.
+		r.openedOn = info.ModTime()
+	}
+	return nil
+}
+
+// Write appends p, rotating first when p would push the file past MaxSize or the day has changed.
+// A single write larger than MaxSize is written to a fresh file rather than split.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.ensureOpenLocked(); err != nil {
+		return 0, err
+	}
+
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// ensureOpenLocked reopens Filename if a failed rotation or reopen left no file open,
+// so that writes recover once the underlying problem is gone.
+func (r *RotatingFile) ensureOpenLocked() error {
+	if r.closed || r.mill == nil {
+		return os.ErrClosed
+	}
+	if r.file == nil {
+		return r.openLocked()
+	}
+	return nil
+}
+
+func (r *RotatingFile) shouldRotate(incoming int64) bool {
+	if r.MaxSize > 0 && r.size > 0 && r.size+incoming > r.MaxSize {
+		return true
+	}
+	if r.Daily {
+		y1, m1, d1 := r.openedOn.Date()
+		y2, m2, d2 := r.now().Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+// Rotate moves the current file to a backup and starts a new one.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.ensureOpenLocked(); err != nil {
+		return err
+	}
+	return r.rotateLocked()
+}
+
+// rotateLocked moves the file to a backup and opens a new one. If the rename fails the
+// original file is reopened; if no file could be opened, the next write retries.
+func (r *RotatingFile) rotateLocked() error {
+	err := r.file.Close()
+	r.file = nil
+	if err != nil {
+		return ungerr.Wrap(err, "error closing log file")
+	}
+	backup := r.backupName(r.now())
+	if err := os.Rename(r.Filename, backup); err != nil {
+		return errors.Join(ungerr.Wrap(err, "error renaming log file"), r.openLocked())
+	}
+	if err := r.openLocked(); err != nil {
+		return err
+	}
+	r.mill <- backup
+	return nil
+}
+
+// Reopen closes and reopens Filename without rotating, picking up a file moved by another tool.
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.mill == nil {
+		return os.ErrClosed
+	}
+	if r.file != nil {
+		err := r.file.Close()
+		r.file = nil
+		if err != nil {
+			return ungerr.Wrap(err, "error closing log file")
+		}
+	}
+	return r.openLocked()
+}
+
+// Close stops signal handling, waits for pending compression and closes the file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	r.mu.Unlock()
+
+	if r.signals != nil {
+		signal.Stop(r.signals)
+		close(r.signals)
+		<-r.sigDone
+	}
+	if r.mill != nil {
+		close(r.mill)
+		<-r.millDone
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) watchSignals() {
+	defer close(r.sigDone)
+	for range r.signals {
+		_ = r.Reopen()
+	}
+}
+
+// backupName returns "<dir>/<name>-<timestamp><ext>", adding a counter if the name is taken.
+func (r *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := r.backupPattern()
+	name := prefix + t.Format(backupTimeFormat)
+	candidate := name + ext
+	for i := 1; fileExists(candidate) || fileExists(candidate+".gz"); i++ {
+		candidate = name + "-" + strconv.Itoa(i) + ext
+	}
+	return candidate
+}
+
+func (r *RotatingFile) backupPattern() (string, string) {
+	ext := filepath.Ext(r.Filename)
+	return strings.TrimSuffix(r.Filename, ext) + "-", ext
+}
+
+func (r *RotatingFile) runMill() {
+	defer close(r.millDone)
+	for backup := range r.mill {
+		if r.Compress {
+			_ = compressFile(backup)
+		}
+		_ = r.pruneBackups()
+	}
+}
+
+// pruneBackups removes the oldest backups beyond MaxBackups.
+func (r *RotatingFile) pruneBackups() error {
+	if r.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := r.Backups()
+	if err != nil {
+		return err
+	}
+	if len(backups) <= r.MaxBackups {
+		return nil
+	}
+	var errs []error
+	for _, backup := range backups[:len(backups)-r.MaxBackups] {
+		if err := os.Remove(backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backupFile is a backup path with the rotation time and collision counter parsed from its name.
+type backupFile struct {
+	path    string
+	time    time.Time
+	counter int
+}
+
+// Backups returns the paths of existing backups, oldest first. Only names in the layout
+// written by backupName count, so files of other loggers sharing the directory and a
+// name prefix are ignored.
+func (r *RotatingFile) Backups() ([]string, error) {
+	dir := filepath.Dir(r.Filename)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, ungerr.Wrap(err, "error listing log directory")
+	}
+	var found []backupFile
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if backup, ok := r.parseBackup(e.Name()); ok {
+			backup.path = filepath.Join(dir, e.Name())
+			found = append(found, backup)
+		}
+	}
+	slices.SortFunc(found, func(a, b backupFile) int {
+		if c := a.time.Compare(b.time); c != 0 {
+			return c
+		}
+		return a.counter - b.counter
+	})
+	backups := make([]string, len(found))
+	for i, backup := range found {
+		backups[i] = backup.path
+	}
+	return backups, nil
+}
+
+// parseBackup reports whether name is "<name>-<timestamp>[-<counter>]<ext>[.gz]".
+func (r *RotatingFile) parseBackup(name string) (backupFile, bool) {
+	prefix, ext := r.backupPattern()
+	rest, ok := strings.CutPrefix(name, filepath.Base(prefix))
+	if !ok {
+		return backupFile{}, false
+	}
+	rest = strings.TrimSuffix(rest, ".gz")
+	if rest, ok = strings.CutSuffix(rest, ext); !ok || len(rest) < len(backupTimeFormat) {
+		return backupFile{}, false
+	}
+	t, err := time.ParseInLocation(backupTimeFormat, rest[:len(backupTimeFormat)], time.Local)
+	if err != nil {
+		return backupFile{}, false
+	}
+	backup := backupFile{time: t}
+	if suffix := rest[len(backupTimeFormat):]; suffix != "" {
+		digits, ok := strings.CutPrefix(suffix, "-")
+		n, err := strconv.Atoi(digits)
+		if !ok || err != nil || n < 1 || strconv.Itoa(n) != digits {
+			return backupFile{}, false
+		}
+		backup.counter = n
+	}
+	return backup, true
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		_ = src.Close()
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err := errors.Join(err, gz.Close(), dst.Close(), src.Close()); err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,296 @@
+package internal_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable time source for rotation tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRotatingFileEmptyName(t *testing.T) {
+	r := &internal.RotatingFile{}
+	assert.Error(t, r.Open(false))
+}
+
+func TestRotatingFileCreatesDirectoryAndAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0o644))
+
+	r := &internal.RotatingFile{Filename: path}
+	require.NoError(t, r.Open(false))
+	_, err := r.Write([]byte("appended\n"))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, "existing\nappended\n", readFile(t, path))
+
+	_, err = r.Write([]byte("closed\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)}
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, MaxSize: 10, Now: clock.Now}
+	require.NoError(t, r.Open(false))
+
+	_, _ = r.Write([]byte("12345\n"))
+	_, _ = r.Write([]byte("1234\n"))
+	clock.Add(time.Second)
+	_, _ = r.Write([]byte("abcdefgh\n"))
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "app-20260102T150405.000.log"), backups[0])
+	assert.Equal(t, "12345\n", readFile(t, backups[0]))
+	assert.Equal(t, "1234\n", readFile(t, backups[1]))
+	assert.Equal(t, "abcdefgh\n", readFile(t, path))
+}
+
+func TestRotatingFileRotatesDaily(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 23, 59, 0, 0, time.Local)}
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, Daily: true, Now: clock.Now}
+	require.NoError(t, r.Open(false))
+
+	_, _ = r.Write([]byte("day one\n"))
+	clock.Add(30 * time.Second)
+	_, _ = r.Write([]byte("still day one\n"))
+	clock.Add(time.Minute)
+	_, _ = r.Write([]byte("day two\n"))
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "day one\nstill day one\n", readFile(t, backups[0]))
+	assert.Equal(t, "day two\n", readFile(t, path))
+}
+
+func TestRotatingFileRotatesDailyAfterRestart(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 4, 9, 0, 0, 0, time.Local)}
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("two days ago\n"), 0o644))
+	lastWrite := clock.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(path, lastWrite, lastWrite))
+
+	r := &internal.RotatingFile{Filename: path, Daily: true, Now: clock.Now}
+	require.NoError(t, r.Open(false))
+	_, _ = r.Write([]byte("today\n"))
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "two days ago\n", readFile(t, backups[0]))
+	assert.Equal(t, "today\n", readFile(t, path))
+}
+
+func TestRotatingFileKeepsMaxBackups(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)}
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, MaxBackups: 2, Now: clock.Now}
+	require.NoError(t, r.Open(false))
+
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n"} {
+		_, _ = r.Write([]byte(line))
+		clock.Add(time.Second)
+		require.NoError(t, r.Rotate())
+	}
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "three\n", readFile(t, backups[0]))
+	assert.Equal(t, "four\n", readFile(t, backups[1]))
+}
+
+func TestRotatingFileCompressesBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, Compress: true}
+	require.NoError(t, r.Open(false))
+
+	_, _ = r.Write([]byte("compressed line\n"))
+	require.NoError(t, r.Rotate())
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.True(t, strings.HasSuffix(backups[0], ".log.gz"))
+
+	f, err := os.Open(backups[0])
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "compressed line\n", string(data))
+}
+
+func TestRotatingFileBackupNameCollision(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)}
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, Now: clock.Now}
+	require.NoError(t, r.Open(false))
+
+	require.NoError(t, r.Rotate())
+	require.NoError(t, r.Rotate())
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestRotatingFileIgnoresUnrelatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"app-20260102T000000.000.log":   "first\n",
+		"app-20260102T000000.000-1.log": "second\n",
+		"app-errors.log":                "other logger\n",
+		"app-2026.log":                  "other logger\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 0, 0, 1, 0, time.Local)}
+	path := filepath.Join(dir, "app.log")
+	r := &internal.RotatingFile{Filename: path, MaxBackups: 2, Now: clock.Now}
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "first\n", readFile(t, backups[0]))
+	assert.Equal(t, "second\n", readFile(t, backups[1]))
+
+	require.NoError(t, r.Open(false))
+	_, _ = r.Write([]byte("third\n"))
+	require.NoError(t, r.Rotate())
+	require.NoError(t, r.Close())
+
+	backups, err = r.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "second\n", readFile(t, backups[0]))
+	assert.Equal(t, "third\n", readFile(t, backups[1]))
+	assert.FileExists(t, filepath.Join(dir, "app-errors.log"))
+	assert.FileExists(t, filepath.Join(dir, "app-2026.log"))
+}
+
+func TestRotatingFileRecoversFromFailedRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	var removeOnce sync.Once
+	var arm bool
+	r := &internal.RotatingFile{Filename: path, Now: func() time.Time {
+		if arm {
+			// Remove the file while it is being rotated so that the rename fails.
+			removeOnce.Do(func() { _ = os.Remove(path) })
+		}
+		return time.Now()
+	}}
+	require.NoError(t, r.Open(false))
+
+	arm = true
+	assert.Error(t, r.Rotate())
+	_, err := r.Write([]byte("after failed rotation\n"))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, "after failed rotation\n", readFile(t, path))
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	r := &internal.RotatingFile{Filename: path}
+	require.NoError(t, r.Open(false))
+
+	_, _ = r.Write([]byte("before move\n"))
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "moved.log")))
+	require.NoError(t, r.Reopen())
+	_, _ = r.Write([]byte("after reopen\n"))
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, "before move\n", readFile(t, filepath.Join(dir, "moved.log")))
+	assert.Equal(t, "after reopen\n", readFile(t, path))
+}
+
+func TestRotatingFileReopenOnSIGHUP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not delivered on windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	r := &internal.RotatingFile{Filename: path}
+	require.NoError(t, r.Open(true))
+	defer r.Close()
+
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "moved.log")))
+	self, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, self.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSimpleLoggerRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &internal.RotatingFile{Filename: path, MaxSize: 64}
+	require.NoError(t, r.Open(false))
+	logger := &internal.SimpleLogger{Namespace: "TEST", Writer: r}
+
+	for range 5 {
+		logger.Info("line that is long enough to rotate")
+	}
+	require.NoError(t, r.Close())
+
+	backups, err := r.Backups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 4)
+	assert.Contains(t, readFile(t, path), "[TEST INFO] line that is long enough to rotate")
+}
@@ -3,8 +3,12 @@ package internal
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,6 +26,33 @@ type SimpleLogger struct {
 	Namespace string
 	UseColor  bool
 	MinLevel  int
+	// LevelVar, when set, takes precedence over MinLevel and can be changed while logging.
+	// Children created with With share it.
+	LevelVar *atomic.Int32
+	// NamespaceLevels, when set, overrides the level for matching namespace prefixes.
+	// Children created with With or Named share it.
+	NamespaceLevels *NamespaceLevels
+	// Writer receives every entry; nil means os.Stdout. Writes are not serialized, so wrap
+	// writers that are not safe for concurrent use with NewSyncWriter.
+	Writer io.Writer
+	// ErrWriter receives WARN and above when set, instead of Writer. It is written like Writer.
+	ErrWriter io.Writer
+	// ExitFunc is called with exit code 1 after a fatal entry is written; nil means os.Exit.
+	ExitFunc func(code int)
+	// AddCaller adds the file:line and function that made the logging call.
+	AddCaller bool
+	// AddStacktrace attaches a stack trace to entries at StacktraceLevel and above.
+	// When an argument or field is an error that already carries a trace, such as an
+	// ungerr.UnknownError, that trace is used instead.
+	AddStacktrace   bool
+	StacktraceLevel int
+	// Async, when set, queues entries for a background goroutine instead of writing them inline.
+	// Children created with With or Named share it.
+	Async *AsyncWriter
+	// Encoding selects the output format; the zero value is EncodingText.
+	Encoding Encoding
+	// Fields holds alternating key-value pairs attached to every entry.
+	Fields []any
 }
 
 var colors = map[logLevel]string{
@@ -40,75 +71,263 @@ var levelToInt = map[logLevel]int{
 	levelFatal: 4,
 }
 
-func (s *SimpleLogger) output(level logLevel, msg string) {
-	if levelToInt[level] < s.MinLevel {
+// Enabled reports whether entries at the given level would be written.
+func (s *SimpleLogger) Enabled(level int) bool {
+	return level >= s.CurrentLevel()
+}
+
+// CurrentLevel returns the minimum level currently written for this logger's namespace.
+func (s *SimpleLogger) CurrentLevel() int {
+	if s.NamespaceLevels != nil {
+		if level, ok := s.NamespaceLevels.Lookup(s.Namespace); ok {
+			return level
+		}
+	}
+	if s.LevelVar != nil {
+		return int(s.LevelVar.Load())
+	}
+	return s.MinLevel
+}
+
+// SetNamespaceLevel overrides the minimum level for prefix and the namespaces below it,
+// regardless of the logger level. It allocates NamespaceLevels when unset, which is not safe
+// for concurrent use.
+func (s *SimpleLogger) SetNamespaceLevel(prefix string, level int) {
+	if s.NamespaceLevels == nil {
+		s.NamespaceLevels = &NamespaceLevels{}
+	}
+	s.NamespaceLevels.Set(prefix, level)
+}
+
+// ClearNamespaceLevel removes the override for prefix.
+func (s *SimpleLogger) ClearNamespaceLevel(prefix string) {
+	if s.NamespaceLevels != nil {
+		s.NamespaceLevels.Delete(prefix)
+	}
+}
+
+// SetMinLevel changes the minimum level. It is safe for concurrent use only when LevelVar is set;
+// otherwise it updates MinLevel directly.
+func (s *SimpleLogger) SetMinLevel(level int) {
+	if s.LevelVar != nil {
+		s.LevelVar.Store(int32(level))
+		return
+	}
+	s.MinLevel = level
+}
+
+// output writes an entry. args are the values the message was built from; they are only
+// inspected for errors carrying their own stack trace.
+func (s *SimpleLogger) output(level logLevel, msg string, args []any, fields []any) {
+	if !s.Enabled(levelToInt[level]) {
+		return
+	}
+	e := entry{
+		time:      time.Now(),
+		level:     level,
+		namespace: s.Namespace,
+		msg:       msg,
+		fields:    s.Fields,
+	}
+	if len(fields) > 0 {
+		e.fields = append(slices.Clip(s.Fields), fields...)
+	}
+	if s.AddCaller || (s.AddStacktrace && levelToInt[level] >= s.StacktraceLevel) {
+		frames := callerFrames()
+		if s.AddCaller && len(frames) > 0 {
+			e.caller = &frames[0]
+		}
+		if s.AddStacktrace && levelToInt[level] >= s.StacktraceLevel {
+			e.stack = errorStack(args, e.fields)
+			if e.stack == "" {
+				e.stack = formatStack(frames)
+			}
+		}
+	}
+	var line []byte
+	if s.Encoding == EncodingJSON {
+		line = encodeJSON(e)
+	} else {
+		line = encodeText(e, s.UseColor)
+	}
+	s.write(level, line)
+}
+
+func (s *SimpleLogger) exit() {
+	_ = s.Flush()
+	if s.ExitFunc != nil {
+		s.ExitFunc(1)
+		return
+	}
+	os.Exit(1)
+}
+
+// SyncWriter serializes writes to W so that entries from concurrent goroutines, including
+// loggers sharing it through With and Named, are never interleaved. Each destination has its
+// own lock, so a slow writer does not hold up loggers writing elsewhere.
+type SyncWriter struct {
+	mu sync.Mutex
+	W  io.Writer
+}
+
+// NewSyncWriter wraps w, returning it unchanged when it already is a *SyncWriter.
+func NewSyncWriter(w io.Writer) *SyncWriter {
+	if sw, ok := w.(*SyncWriter); ok {
+		return sw
+	}
+	return &SyncWriter{W: w}
+}
+
+func (w *SyncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.W.Write(p)
+}
+
+// stdoutMu serializes writes of loggers without a Writer. os.Stdout is looked up on every
+// write so that redirecting it takes effect.
+var stdoutMu sync.Mutex
+
+type stdoutWriter struct{}
+
+func (stdoutWriter) Write(p []byte) (int, error) {
+	stdoutMu.Lock()
+	defer stdoutMu.Unlock()
+	return os.Stdout.Write(p)
+}
+
+// write sends line to the destination for level. Writers are expected to be safe for
+// concurrent use, such as a *SyncWriter.
+func (s *SimpleLogger) write(level logLevel, line []byte) {
+	var w io.Writer = stdoutWriter{}
+	if s.Writer != nil {
+		w = s.Writer
+	}
+	if s.ErrWriter != nil && levelToInt[level] >= levelToInt[levelWarn] {
+		w = s.ErrWriter
+	}
+	if s.Async != nil {
+		s.Async.Enqueue(w, line)
 		return
 	}
-	var colorStart, colorReset string
-	if s.UseColor {
-		colorStart = colors[level]
-		colorReset = "\033[0m"
+	_, _ = w.Write(line)
+}
+
+// Flush waits until entries queued by Async have been written. It is a no-op for synchronous loggers.
+func (s *SimpleLogger) Flush() error {
+	if s.Async != nil {
+		s.Async.Flush()
+	}
+	return nil
+}
+
+// Dropped returns the number of entries Async discarded under its overflow policy. It is
+// zero for synchronous loggers.
+func (s *SimpleLogger) Dropped() uint64 {
+	if s.Async != nil {
+		return s.Async.Dropped()
 	}
-	fmt.Printf("%s%s [%s %s] %s%s\n", time.Now().Format("15:04:05.000"), colorStart, s.Namespace, level, msg, colorReset)
+	return 0
+}
+
+// Close flushes and stops Async. Later entries are written synchronously.
+func (s *SimpleLogger) Close() error {
+	if s.Async != nil {
+		s.Async.Close()
+	}
+	return nil
 }
 
 func (s *SimpleLogger) outputf(level logLevel, format string, args ...any) {
-	if levelToInt[level] < s.MinLevel {
+	if !s.Enabled(levelToInt[level]) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	s.output(level, msg)
+	s.output(level, msg, args, nil)
+}
+
+// With returns a copy of the logger that attaches the given key-value pairs to every entry.
+func (s *SimpleLogger) With(fields ...any) *SimpleLogger {
+	clone := *s
+	clone.Fields = append(slices.Clip(s.Fields), fields...)
+	return &clone
+}
+
+// Named returns a copy of the logger whose namespace has name appended with a dot,
+// such as "app.billing" for Named("billing") on "app".
+func (s *SimpleLogger) Named(name string) *SimpleLogger {
+	clone := *s
+	clone.Namespace = JoinNamespace(s.Namespace, name)
+	return &clone
 }
 
 // Debug logs a debug message.
 func (s *SimpleLogger) Debug(args ...any) {
-	s.output(levelDebug, strings.TrimRight(fmt.Sprintln(args...), "\n"))
+	s.output(levelDebug, strings.TrimRight(fmt.Sprintln(args...), "\n"), args, nil)
 }
 
 // Info logs an info message.
 func (s *SimpleLogger) Info(args ...any) {
-	s.output(levelInfo, strings.TrimRight(fmt.Sprintln(args...), "\n"))
+	s.output(levelInfo, strings.TrimRight(fmt.Sprintln(args...), "\n"), args, nil)
 }
 
 // Warn logs a warning message.
 func (s *SimpleLogger) Warn(args ...any) {
-	s.output(levelWarn, strings.TrimRight(fmt.Sprintln(args...), "\n"))
+	s.output(levelWarn, strings.TrimRight(fmt.Sprintln(args...), "\n"), args, nil)
 }
 
 // Error logs an error message.
 func (s *SimpleLogger) Error(args ...any) {
-	s.output(levelError, strings.TrimRight(fmt.Sprintln(args...), "\n"))
+	s.output(levelError, strings.TrimRight(fmt.Sprintln(args...), "\n"), args, nil)
 }
 
-// Fatal logs a fatal message and exits the program.
+// Fatal logs a fatal message and exits the program through ExitFunc.
 func (s *SimpleLogger) Fatal(args ...any) {
-	s.output(levelFatal, strings.TrimRight(fmt.Sprintln(args...), "\n"))
-	os.Exit(1)
+	s.output(levelFatal, strings.TrimRight(fmt.Sprintln(args...), "\n"), args, nil)
+	s.exit()
 }
 
 // Debugf logs a formatted debug message.
 func (s *SimpleLogger) Debugf(format string, args ...any) { s.outputf(levelDebug, format, args...) }
 
 // Infof logs a formatted info message.
-func (s *SimpleLogger) Infof(format string, args ...any)  { s.outputf(levelInfo, format, args...) }
+func (s *SimpleLogger) Infof(format string, args ...any) { s.outputf(levelInfo, format, args...) }
 
 // Warnf logs a formatted warning message.
-func (s *SimpleLogger) Warnf(format string, args ...any)  { s.outputf(levelWarn, format, args...) }
+func (s *SimpleLogger) Warnf(format string, args ...any) { s.outputf(levelWarn, format, args...) }
 
 // Errorf logs a formatted error message.
 func (s *SimpleLogger) Errorf(format string, args ...any) { s.outputf(levelError, format, args...) }
 
-// Fatalf logs a formatted fatal message and exits the program.
+// Fatalf logs a formatted fatal message and exits the program through ExitFunc.
 func (s *SimpleLogger) Fatalf(format string, args ...any) {
 	s.outputf(levelFatal, format, args...)
-	os.Exit(1)
+	s.exit()
+}
+
+// Debugw logs a debug message with key-value pairs.
+func (s *SimpleLogger) Debugw(msg string, fields ...any) { s.output(levelDebug, msg, nil, fields) }
+
+// Infow logs an info message with key-value pairs.
+func (s *SimpleLogger) Infow(msg string, fields ...any) { s.output(levelInfo, msg, nil, fields) }
+
+// Warnw logs a warning message with key-value pairs.
+func (s *SimpleLogger) Warnw(msg string, fields ...any) { s.output(levelWarn, msg, nil, fields) }
+
+// Errorw logs an error message with key-value pairs.
+func (s *SimpleLogger) Errorw(msg string, fields ...any) { s.output(levelError, msg, nil, fields) }
+
+// Fatalw logs a fatal message with key-value pairs and exits the program through ExitFunc.
+func (s *SimpleLogger) Fatalw(msg string, fields ...any) {
+	s.output(levelFatal, msg, nil, fields)
+	s.exit()
 }
 
 // Print logs a message using Info level (goose.Logger interface).
-func (s *SimpleLogger) Print(args ...any)                 { s.Info(fmt.Sprint(args...)) }
+func (s *SimpleLogger) Print(args ...any) { s.Info(fmt.Sprint(args...)) }
 
 // Println logs a message using Info level (goose.Logger interface).
-func (s *SimpleLogger) Println(args ...any)               { s.Info(strings.TrimRight(fmt.Sprintln(args...), "\n")) }
+func (s *SimpleLogger) Println(args ...any) { s.Info(strings.TrimRight(fmt.Sprintln(args...), "\n")) }
 
 // Printf logs a formatted message using Info level (goose.Logger interface).
 func (s *SimpleLogger) Printf(format string, args ...any) { s.Infof(format, args...) }
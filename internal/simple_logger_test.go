@@ -2,11 +2,16 @@ package internal_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/itsLeonB/ezutil/v2/internal"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +19,13 @@ import (
 
 var expectFmtOutputMsg = "Expected formatted output"
 
+// pointerError dereferences its receiver, so a typed nil *pointerError panics in Error.
+type pointerError struct {
+	msg string
+}
+
+func (e *pointerError) Error() string { return e.msg }
+
 // captureOutput captures stdout for testing
 func captureOutput(f func()) string {
 	old := os.Stdout
@@ -327,10 +339,59 @@ func TestSimpleLoggerColorCodes(t *testing.T) {
 	}
 }
 
-// Note: Testing Fatal and Fatalf methods that call os.Exit(1) would terminate the test process.
-// In a real-world scenario, you might want to refactor the logger to accept an interface
-// for exiting (dependency injection) to make it testable, or use integration tests
-// that run the code in a separate process.
+func TestSimpleLoggerFatal(t *testing.T) {
+	tests := []struct {
+		name    string
+		logFunc func(*internal.SimpleLogger)
+		message string
+	}{
+		{"Fatal", func(l *internal.SimpleLogger) { l.Fatal("fatal", "message") }, "fatal message"},
+		{"Fatalf", func(l *internal.SimpleLogger) { l.Fatalf("fatal %d", 1) }, "fatal 1"},
+		{"Fatalw", func(l *internal.SimpleLogger) { l.Fatalw("fatal", "key", "value") }, "fatal key=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []int
+			logger := &internal.SimpleLogger{
+				Namespace: "TEST",
+				ExitFunc:  func(code int) { codes = append(codes, code) },
+			}
+
+			output := captureOutput(func() {
+				tt.logFunc(logger)
+			})
+
+			assert.Contains(t, output, "[TEST FATAL] "+tt.message)
+			assert.Equal(t, []int{1}, codes)
+		})
+	}
+
+	t.Run("exit func can panic", func(t *testing.T) {
+		logger := &internal.SimpleLogger{
+			Namespace: "TEST",
+			Writer:    io.Discard,
+			ExitFunc:  func(code int) { panic(fmt.Sprintf("exit %d", code)) },
+		}
+
+		assert.PanicsWithValue(t, "exit 1", func() {
+			logger.Fatal("boom")
+		})
+	})
+
+	t.Run("children inherit exit func", func(t *testing.T) {
+		exited := false
+		logger := &internal.SimpleLogger{
+			Namespace: "TEST",
+			Writer:    io.Discard,
+			ExitFunc:  func(int) { exited = true },
+		}
+
+		logger.With("k", "v").Fatal("boom")
+
+		assert.True(t, exited)
+	})
+}
 
 // Benchmark tests
 func BenchmarkSimpleLoggerInfo(b *testing.B) {
@@ -371,3 +432,266 @@ func BenchmarkSimpleLoggerFilteredOut(b *testing.B) {
 		logger.Debug("this should be filtered out")
 	}
 }
+
+func TestSimpleLoggerStructuredFields(t *testing.T) {
+	logger := &internal.SimpleLogger{
+		Namespace: "TEST",
+		UseColor:  false,
+		MinLevel:  0,
+	}
+
+	t.Run("renders key=value pairs in order", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.Infow("user logged in", "user_id", 42, "ok", true)
+		})
+
+		assert.Contains(t, output, "[TEST INFO] user logged in user_id=42 ok=true")
+	})
+
+	t.Run("quotes values that need it", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.Warnw("slow query", "query", "select 1", "empty", "", "err", errors.New("a=b"))
+		})
+
+		assert.Contains(t, output, `query="select 1" empty="" err="a=b"`)
+	})
+
+	t.Run("renders typed nil errors", func(t *testing.T) {
+		var err *pointerError
+		output := captureOutput(func() {
+			logger.Infow("typed nil", "err", err)
+		})
+
+		assert.Contains(t, output, "typed nil err=<nil>")
+	})
+
+	t.Run("reports malformed pairs", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.Errorw("bad", 1, "dangling")
+		})
+
+		assert.Contains(t, output, "!BADKEY=1 !BADKEY=dangling")
+	})
+
+	t.Run("With attaches fields to every entry", func(t *testing.T) {
+		child := logger.With("request_id", "r-1")
+
+		output := captureOutput(func() {
+			child.Info("first")
+			child.Debugw("second", "step", 2)
+			logger.Info("parent")
+		})
+
+		assert.Contains(t, output, "first request_id=r-1")
+		assert.Contains(t, output, "second request_id=r-1 step=2")
+		assert.Contains(t, output, "[TEST INFO] parent\n")
+	})
+
+	t.Run("With does not share backing storage", func(t *testing.T) {
+		base := logger.With("a", 1)
+		left := base.With("b", 2)
+		right := base.With("c", 3)
+
+		output := captureOutput(func() {
+			left.Info("left")
+			right.Info("right")
+		})
+
+		assert.Contains(t, output, "left a=1 b=2")
+		assert.Contains(t, output, "right a=1 c=3")
+	})
+}
+
+func TestSimpleLoggerJSONEncoding(t *testing.T) {
+	logger := &internal.SimpleLogger{
+		Namespace: "TEST",
+		UseColor:  true,
+		MinLevel:  0,
+		Encoding:  internal.EncodingJSON,
+	}
+
+	t.Run("writes one object per line", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.With("request_id", "r-1").Infow("hello \"world\"", "count", 3, "err", errors.New("boom"))
+			logger.Warnf("temp %d", 35)
+		})
+
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		assert.Len(t, lines, 2)
+
+		var first map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, "INFO", first["level"])
+		assert.Equal(t, "TEST", first["namespace"])
+		assert.Equal(t, "hello \"world\"", first["msg"])
+		assert.Equal(t, "r-1", first["request_id"])
+		assert.Equal(t, float64(3), first["count"])
+		assert.Equal(t, "boom", first["err"])
+		_, err := time.Parse(time.RFC3339Nano, first["time"].(string))
+		assert.NoError(t, err)
+
+		var second map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+		assert.Equal(t, "WARN", second["level"])
+		assert.Equal(t, "temp 35", second["msg"])
+	})
+
+	t.Run("keeps field order and ignores colors", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.Infow("ordered", "b", 1, "a", 2)
+		})
+
+		assert.NotContains(t, output, "\033[")
+		assert.Contains(t, output, `"msg":"ordered","b":1,"a":2}`)
+	})
+
+	t.Run("prefixes fields colliding with entry keys", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.With("level", "debug").Infow("real", "msg", "fake", "time", 1, "namespace", "x", "caller", "y", "stacktrace", "z")
+		})
+
+		assert.Equal(t, 1, strings.Count(output, `"msg":`))
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(output), &decoded))
+		assert.Equal(t, "INFO", decoded["level"])
+		assert.Equal(t, "real", decoded["msg"])
+		assert.Equal(t, "TEST", decoded["namespace"])
+		assert.Equal(t, "debug", decoded["fields.level"])
+		assert.Equal(t, "fake", decoded["fields.msg"])
+		assert.Equal(t, float64(1), decoded["fields.time"])
+		assert.Equal(t, "x", decoded["fields.namespace"])
+		assert.Equal(t, "y", decoded["fields.caller"])
+		assert.Equal(t, "z", decoded["fields.stacktrace"])
+	})
+
+	t.Run("writes typed nil errors", func(t *testing.T) {
+		var err *pointerError
+		output := captureOutput(func() {
+			logger.Errorw("typed nil", "err", err)
+		})
+
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(output), &decoded))
+		assert.Equal(t, "<nil>", decoded["err"])
+	})
+
+	t.Run("falls back to string for unsupported values", func(t *testing.T) {
+		output := captureOutput(func() {
+			logger.Infow("chan", "ch", make(chan int))
+		})
+
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(output), &decoded))
+		assert.IsType(t, "", decoded["ch"])
+	})
+}
+
+func TestSimpleLoggerWriters(t *testing.T) {
+	t.Run("writes to the configured writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf}
+
+		stdout := captureOutput(func() {
+			logger.Info("buffered")
+		})
+
+		assert.Empty(t, stdout)
+		assert.Contains(t, buf.String(), "[TEST INFO] buffered")
+	})
+
+	t.Run("routes warn and above to the error writer", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &out, ErrWriter: &errOut}
+
+		logger.Debug("debug")
+		logger.Info("info")
+		logger.Warn("warn")
+		logger.Error("error")
+
+		assert.Equal(t, 2, strings.Count(out.String(), "\n"))
+		assert.Contains(t, out.String(), "info")
+		assert.NotContains(t, out.String(), "warn")
+		assert.Contains(t, errOut.String(), "warn")
+		assert.Contains(t, errOut.String(), "error")
+	})
+
+	t.Run("children share the writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf}
+
+		logger.With("k", "v").Info("child")
+
+		assert.Contains(t, buf.String(), "child k=v")
+	})
+
+	t.Run("concurrent writes never interleave", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: internal.NewSyncWriter(&buf)}
+
+		var wg sync.WaitGroup
+		for g := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				child := logger.With("goroutine", g)
+				for i := range 100 {
+					child.Infow("concurrent entry", "i", i)
+				}
+			}()
+		}
+		wg.Wait()
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 800)
+		for _, line := range lines {
+			assert.Contains(t, line, "[TEST INFO] concurrent entry goroutine=")
+		}
+	})
+}
+
+func TestSimpleLoggerLevelVar(t *testing.T) {
+	t.Run("LevelVar takes precedence and is shared with children", func(t *testing.T) {
+		var buf bytes.Buffer
+		levelVar := new(atomic.Int32)
+		levelVar.Store(2)
+		logger := &internal.SimpleLogger{Namespace: "TEST", Writer: &buf, MinLevel: 0, LevelVar: levelVar}
+		child := logger.With("k", "v")
+
+		child.Info("hidden")
+		logger.SetMinLevel(1)
+		child.Info("shown")
+
+		assert.Equal(t, 1, logger.CurrentLevel())
+		assert.Equal(t, 1, child.CurrentLevel())
+		assert.NotContains(t, buf.String(), "hidden")
+		assert.Contains(t, buf.String(), "shown")
+	})
+
+	t.Run("without LevelVar SetMinLevel updates MinLevel", func(t *testing.T) {
+		logger := &internal.SimpleLogger{Namespace: "TEST", MinLevel: 0}
+
+		logger.SetMinLevel(3)
+
+		assert.Equal(t, 3, logger.MinLevel)
+		assert.False(t, logger.Enabled(2))
+		assert.True(t, logger.Enabled(3))
+	})
+}
+
+func TestSimpleLoggerNamed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &internal.SimpleLogger{Namespace: "app", Writer: &buf, MinLevel: 1}
+
+	child := logger.Named("billing").With("k", "v").Named("stripe")
+	child.SetNamespaceLevel("app.billing", 0)
+
+	child.Debug("nested debug")
+	logger.Debug("root debug")
+
+	assert.Equal(t, "app", logger.Namespace)
+	assert.Contains(t, buf.String(), "[app.billing.stripe DEBUG] nested debug k=v")
+	assert.NotContains(t, buf.String(), "root debug")
+
+	child.ClearNamespaceLevel("app.billing")
+	assert.False(t, child.Enabled(0))
+}
@@ -1,13 +1,47 @@
 package ezutil
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
 type Job struct {
-	logger      Logger
-	setupFunc   func() error
-	runFunc     func() error
-	cleanupFunc func() error
+	logger       Logger
+	setupFunc    func(ctx context.Context) error
+	runFunc      func(ctx context.Context) error
+	cleanupFunc  func(ctx context.Context) error
+	setupRetry   RetryPolicy
+	runRetry     RetryPolicy
+	timeout      time.Duration
+	signals      []os.Signal
+	locker       Locker
+	lockKey      string
+	lockTTL      time.Duration
+	recorder     RunRecorder
+	metrics      Metrics
+	recordName   string
+	metricsName  string
+	beforeHooks  []func(ctx context.Context)
+	afterHooks   []func(ctx context.Context, result JobResult)
+	errorHooks   []func(ctx context.Context, err error)
+	successHooks []func(ctx context.Context, result JobResult)
 }
 
 func NewJob(logger Logger, runFunc func() error) *Job {
+	if runFunc == nil {
+		panic("runFunc cannot be nil")
+	}
+	return NewJobContext(logger, withoutContext(runFunc))
+}
+
+// NewJobContext is like NewJob for a run function that observes the job's context,
+// which is cancelled on timeout or on a signal registered with WithSignalCancel.
+func NewJobContext(logger Logger, runFunc func(ctx context.Context) error) *Job {
 	if logger == nil {
 		panic("logger cannot be nil")
 	}
@@ -20,45 +54,240 @@ func NewJob(logger Logger, runFunc func() error) *Job {
 	}
 }
 
+func withoutContext(fn func() error) func(ctx context.Context) error {
+	if fn == nil {
+		return nil
+	}
+	return func(context.Context) error { return fn() }
+}
+
 func (j *Job) WithSetupFunc(fn func() error) *Job {
-	j.setupFunc = fn
+	j.setupFunc = withoutContext(fn)
 	return j
 }
 
 func (j *Job) WithCleanupFunc(fn func() error) *Job {
+	j.cleanupFunc = withoutContext(fn)
+	return j
+}
+
+// WithSetupFuncContext is like WithSetupFunc for a setup function that observes the job's context.
+func (j *Job) WithSetupFuncContext(fn func(ctx context.Context) error) *Job {
+	j.setupFunc = fn
+	return j
+}
+
+// WithCleanupFuncContext is like WithCleanupFunc for a cleanup function that receives the job's context.
+// The context is never cancelled, so cleanup still runs after a timeout or signal.
+func (j *Job) WithCleanupFuncContext(fn func(ctx context.Context) error) *Job {
 	j.cleanupFunc = fn
 	return j
 }
 
+// WithRetry retries the run function according to policy before the job is considered failed.
+func (j *Job) WithRetry(policy RetryPolicy) *Job {
+	j.runRetry = policy
+	return j
+}
+
+// WithSetupRetry retries the setup function according to policy before the job is considered failed.
+func (j *Job) WithSetupRetry(policy RetryPolicy) *Job {
+	j.setupRetry = policy
+	return j
+}
+
+// WithTimeout cancels the context passed to setup and run once d has elapsed since the job started.
+func (j *Job) WithTimeout(d time.Duration) *Job {
+	j.timeout = d
+	return j
+}
+
+// WithSignalCancel cancels the context passed to setup and run when one of sigs is received,
+// SIGINT and SIGTERM if none are given. Cleanup still runs before the job exits.
+// A second signal is not intercepted, so it terminates the process as usual.
+func (j *Job) WithSignalCancel(sigs ...os.Signal) *Job {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	j.signals = sigs
+	return j
+}
+
 func (j *Job) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext is like Run with a parent context for the setup, run and cleanup functions.
+func (j *Job) RunContext(ctx context.Context) {
+	if err := j.Execute(ctx).Err(); err != nil {
+		j.logger.Fatalf("%v", err)
+	}
+}
+
+// RunE is like Run but returns the failure instead of exiting the process.
+func (j *Job) RunE() error {
+	return j.Execute(context.Background()).Err()
+}
+
+// JobResult describes a finished job execution.
+type JobResult struct {
+	// SetupErr is the error returned by the last setup attempt. Run and cleanup are skipped when it is set.
+	SetupErr error
+	// RunErr is the error returned by the last run attempt.
+	RunErr error
+	// CleanupErr is the error returned by the cleanup function.
+	CleanupErr error
+	// SetupAttempts and Attempts count the calls made to the setup and run functions.
+	SetupAttempts int
+	Attempts      int
+	// Latency is the time spent running, including retries.
+	Latency time.Duration
+	// SetupLatency and CleanupLatency are the time spent in setup, including retries, and cleanup.
+	SetupLatency   time.Duration
+	CleanupLatency time.Duration
+	// Skipped is set when the job did not run because another owner held its lock.
+	Skipped bool
+	// LockErr is the error from acquiring, renewing or releasing the job's lock.
+	LockErr error
+	// RunID identifies the run with the job's RunRecorder, if it has one.
+	RunID string
+}
+
+// Err joins the lock, setup, run and cleanup errors, or returns nil when the job succeeded.
+func (r JobResult) Err() error {
+	var errs []error
+	if r.LockErr != nil {
+		errs = append(errs, fmt.Errorf("error locking job: %w", r.LockErr))
+	}
+	if r.SetupErr != nil {
+		errs = append(errs, fmt.Errorf("error setting up job: %w", r.SetupErr))
+	}
+	if r.RunErr != nil {
+		errs = append(errs, fmt.Errorf("error running job: %w", r.RunErr))
+	}
+	if r.CleanupErr != nil {
+		errs = append(errs, fmt.Errorf("error cleaning up job: %w", r.CleanupErr))
+	}
+	return errors.Join(errs...)
+}
+
+// Execute runs the job like RunContext and reports the outcome instead of exiting the process,
+// leaving that decision to the caller. Progress and retries are still logged. A panic in the
+// setup, run or cleanup function is recovered and reported as a *PanicError.
+func (j *Job) Execute(ctx context.Context) JobResult {
+	defer func() { _ = flushLogger(j.logger) }()
+
+	j.runBeforeHooks(ctx)
+	run := j.startRecord(ctx)
+	done := j.startMetrics()
+	result := j.executeLocked(ctx)
+	done(result)
+	j.finishRecord(context.WithoutCancel(ctx), run, result)
+	if run != nil {
+		result.RunID = run.ID
+	}
+	j.runAfterHooks(context.WithoutCancel(ctx), result)
+	return result
+}
+
+// executeLocked runs the job while holding its lock, if it has a locker.
+func (j *Job) executeLocked(ctx context.Context) JobResult {
+	runCtx, cancel := j.context(ctx)
+	defer cancel()
+
+	if j.locker == nil {
+		return j.execute(runCtx, ctx)
+	}
+
+	lock, err := j.locker.Acquire(runCtx, j.lockKey, j.lockTTL)
+	if errors.Is(err, ErrLockHeld) {
+		j.logger.Infow("job lock is held by another owner, skipping run", "key", j.lockKey)
+		return JobResult{Skipped: true}
+	}
+	if err != nil {
+		return JobResult{LockErr: err}
+	}
+
+	lockCtx, cancelLock := context.WithCancel(runCtx)
+	defer cancelLock()
+	held := j.keepLock(context.WithoutCancel(runCtx), lock, cancelLock)
+	result := j.execute(lockCtx, ctx)
+	result.LockErr = held.release(context.WithoutCancel(ctx))
+	return result
+}
+
+// execute runs setup, run and cleanup. Setup and run observe runCtx while cleanup gets
+// ctx without its cancellation.
+func (j *Job) execute(runCtx, ctx context.Context) JobResult {
+	var result JobResult
 	if j.setupFunc != nil {
 		j.logger.Info("setting up job...")
-		if err := j.setupFunc(); err != nil {
-			j.logger.Fatalf("error setting up job: %v", err)
+		result.SetupLatency = MeasureLatency(func() {
+			result.SetupAttempts, result.SetupErr = j.retry(runCtx, "setup", j.setupRetry, j.recovering("setup", j.setupFunc))
+		})
+		if result.SetupErr != nil {
+			return result
 		}
 	}
 
 	j.logger.Info("running job...")
 
-	var jobErr error
-
-	latency := MeasureLatency(func() { jobErr = j.runFunc() })
+	result.Latency = MeasureLatency(func() {
+		result.Attempts, result.RunErr = j.retry(runCtx, "run", j.runRetry, j.recovering("run", j.runFunc))
+	})
 
-	if jobErr != nil {
-		j.doCleanup()
-		j.logger.Fatalf("error running job: %v", jobErr)
-		return
+	result.CleanupLatency = MeasureLatency(func() { result.CleanupErr = j.doCleanup(context.WithoutCancel(ctx)) })
+	if result.RunErr != nil || result.CleanupErr != nil {
+		return result
 	}
 
-	j.doCleanup()
-	j.logger.Infof("success running job for %d ms", latency.Milliseconds())
+	if result.Attempts > 1 {
+		j.logger.Infof("success running job for %d ms after %d attempts", result.Latency.Milliseconds(), result.Attempts)
+		return result
+	}
+	j.logger.Infof("success running job for %d ms", result.Latency.Milliseconds())
+	return result
 }
 
-func (j *Job) doCleanup() {
-	if j.cleanupFunc != nil {
-		j.logger.Info("cleaning up job...")
-		if err := j.cleanupFunc(); err != nil {
-			j.logger.Fatalf("error cleaning up job: %v", err)
+// context derives the context for setup and run, applying the timeout and signal handling.
+func (j *Job) context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if j.timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, j.timeout)
+		cancelParent := cancel
+		cancel = func() {
+			cancelTimeout()
+			cancelParent()
+		}
+	}
+	if len(j.signals) == 0 {
+		return ctx, cancel
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, j.signals...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigCh:
+			signal.Stop(sigCh)
+			j.logger.Warnw("received signal, cancelling job", "signal", sig.String())
+			cancel()
+		case <-done:
 		}
+	}()
+	return ctx, func() {
+		signal.Stop(sigCh)
+		close(done)
+		cancel()
+	}
+}
+
+func (j *Job) doCleanup(ctx context.Context) error {
+	if j.cleanupFunc == nil {
+		return nil
 	}
+	j.logger.Info("cleaning up job...")
+	return j.recovering("cleanup", j.cleanupFunc)(ctx)
 }
@@ -0,0 +1,80 @@
+package ezutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithBeforeHook adds fn to the functions called before each execution of the job, ahead of
+// locking and setup. Hooks are called in the order they were added.
+func (j *Job) WithBeforeHook(fn func(ctx context.Context)) *Job {
+	if fn == nil {
+		panic("hook cannot be nil")
+	}
+	j.beforeHooks = append(j.beforeHooks, fn)
+	return j
+}
+
+// WithAfterHook adds fn to the functions called after every execution of the job, whatever its outcome.
+func (j *Job) WithAfterHook(fn func(ctx context.Context, result JobResult)) *Job {
+	if fn == nil {
+		panic("hook cannot be nil")
+	}
+	j.afterHooks = append(j.afterHooks, fn)
+	return j
+}
+
+// WithErrorHook adds fn to the functions called with JobResult.Err after an execution fails.
+func (j *Job) WithErrorHook(fn func(ctx context.Context, err error)) *Job {
+	if fn == nil {
+		panic("hook cannot be nil")
+	}
+	j.errorHooks = append(j.errorHooks, fn)
+	return j
+}
+
+// WithSuccessHook adds fn to the functions called after an execution succeeds. Skipped executions
+// are not successes.
+func (j *Job) WithSuccessHook(fn func(ctx context.Context, result JobResult)) *Job {
+	if fn == nil {
+		panic("hook cannot be nil")
+	}
+	j.successHooks = append(j.successHooks, fn)
+	return j
+}
+
+func (j *Job) runBeforeHooks(ctx context.Context) {
+	for _, hook := range j.beforeHooks {
+		j.callHook("before", func() { hook(ctx) })
+	}
+}
+
+// runAfterHooks calls the error or success hooks matching result, then the after hooks.
+func (j *Job) runAfterHooks(ctx context.Context, result JobResult) {
+	switch result.status() {
+	case RunFailed:
+		err := result.Err()
+		for _, hook := range j.errorHooks {
+			j.callHook("error", func() { hook(ctx, err) })
+		}
+	case RunSucceeded:
+		for _, hook := range j.successHooks {
+			j.callHook("success", func() { hook(ctx, result) })
+		}
+	}
+	for _, hook := range j.afterHooks {
+		j.callHook("after", func() { hook(ctx, result) })
+	}
+}
+
+// callHook calls a hook, logging rather than propagating a panic so that a broken
+// notification does not change the outcome of the job.
+func (j *Job) callHook(kind string, fn func()) {
+	err := callRecover(func() error {
+		fn()
+		return nil
+	})
+	if panicErr, ok := err.(*PanicError); ok {
+		j.logger.Errorw("recovered panic in job hook", "hook", kind, "panic", fmt.Sprint(panicErr.Value), "stack", string(panicErr.Stack))
+	}
+}
@@ -0,0 +1,107 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Hooks(t *testing.T) {
+	var events []string
+	fail := true
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		events = append(events, "run")
+		if fail {
+			return errors.New("timeout")
+		}
+		return nil
+	}).
+		WithBeforeHook(func(context.Context) { events = append(events, "before") }).
+		WithAfterHook(func(_ context.Context, result ezutil.JobResult) {
+			events = append(events, "after")
+		}).
+		WithErrorHook(func(_ context.Context, err error) {
+			events = append(events, "error: "+err.Error())
+		}).
+		WithSuccessHook(func(_ context.Context, result ezutil.JobResult) {
+			events = append(events, "success")
+		})
+
+	require.Error(t, job.RunE())
+	assert.Equal(t, []string{"before", "run", "error: error running job: timeout", "after"}, events)
+
+	events = nil
+	fail = false
+	require.NoError(t, job.RunE())
+	assert.Equal(t, []string{"before", "run", "success", "after"}, events)
+}
+
+func TestJob_Hooks_Order(t *testing.T) {
+	var events []string
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithSuccessHook(func(context.Context, ezutil.JobResult) { events = append(events, "first") }).
+		WithSuccessHook(func(context.Context, ezutil.JobResult) { events = append(events, "second") })
+
+	require.NoError(t, job.RunE())
+	assert.Equal(t, []string{"first", "second"}, events)
+}
+
+func TestJob_Hooks_Skipped(t *testing.T) {
+	ctx := context.Background()
+	locker := ezutil.NewMemoryLocker()
+	lock, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	defer func() { _ = lock.Release(ctx) }()
+
+	var events []string
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithLock(locker, "report", 0).
+		WithErrorHook(func(context.Context, error) { events = append(events, "error") }).
+		WithSuccessHook(func(context.Context, ezutil.JobResult) { events = append(events, "success") }).
+		WithAfterHook(func(_ context.Context, result ezutil.JobResult) {
+			events = append(events, "after")
+			assert.True(t, result.Skipped)
+		}).
+		Execute(ctx)
+
+	assert.True(t, result.Skipped)
+	assert.Equal(t, []string{"after"}, events)
+}
+
+func TestJob_Hooks_PanicDoesNotFailJob(t *testing.T) {
+	afterCalled := false
+	err := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithBeforeHook(func(context.Context) { panic("notifier down") }).
+		WithSuccessHook(func(context.Context, ezutil.JobResult) { panic("notifier down") }).
+		WithAfterHook(func(context.Context, ezutil.JobResult) { afterCalled = true }).
+		RunE()
+
+	assert.NoError(t, err)
+	assert.True(t, afterCalled)
+}
+
+func TestJob_Hooks_AfterContextNotCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var hookErr error
+	ezutil.NewJobContext(ezutil.NewTestLogger(), func(context.Context) error {
+		cancel()
+		return nil
+	}).
+		WithAfterHook(func(ctx context.Context, _ ezutil.JobResult) { hookErr = ctx.Err() }).
+		Execute(ctx)
+
+	assert.NoError(t, hookErr)
+}
+
+func TestJob_Hooks_Nil(t *testing.T) {
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil })
+	assert.PanicsWithValue(t, "hook cannot be nil", func() { job.WithBeforeHook(nil) })
+	assert.PanicsWithValue(t, "hook cannot be nil", func() { job.WithAfterHook(nil) })
+	assert.PanicsWithValue(t, "hook cannot be nil", func() { job.WithErrorHook(nil) })
+	assert.PanicsWithValue(t, "hook cannot be nil", func() { job.WithSuccessHook(nil) })
+}
@@ -0,0 +1,157 @@
+package ezutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrLockHeld is returned by Locker.Acquire when another owner holds the lock.
+	ErrLockHeld = errors.New("lock is held by another owner")
+	// ErrLockLost is returned by Lock.Renew when the lock expired and may have been taken over.
+	ErrLockLost = errors.New("lock was lost")
+)
+
+// Locker hands out locks shared between processes, so that a Job running on several
+// replicas executes on only one of them at a time.
+type Locker interface {
+	// Acquire takes the lock named key for ttl. It returns ErrLockHeld if another owner holds it.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is a lock held through a Locker. It expires unless renewed within its ttl.
+type Lock interface {
+	// Renew extends the lock by ttl from now. It returns ErrLockLost if the lock has expired.
+	Renew(ctx context.Context, ttl time.Duration) error
+	// Release gives up the lock.
+	Release(ctx context.Context) error
+}
+
+// defaultLockTTL is used by WithLock when no ttl is given.
+const defaultLockTTL = 30 * time.Second
+
+// WithLock makes the job acquire the lock named key from locker before setup, renew it while
+// it runs and release it after cleanup. When another replica holds the lock the job is skipped:
+// Execute reports JobResult.Skipped and Run returns without error. The lock is renewed every
+// third of ttl; if it is lost, the context passed to setup and run is cancelled.
+// A ttl of zero means 30 seconds.
+func (j *Job) WithLock(locker Locker, key string, ttl time.Duration) *Job {
+	if locker == nil {
+		panic("locker cannot be nil")
+	}
+	if ttl <= 0 {
+		ttl = defaultLockTTL
+	}
+	j.locker = locker
+	j.lockKey = key
+	j.lockTTL = ttl
+	return j
+}
+
+// heldLock keeps a lock renewed in the background until it is released, including while
+// cleanup runs after the job's context was cancelled.
+type heldLock struct {
+	lock Lock
+	stop chan struct{}
+	done chan struct{}
+	// lost is set by the renewal goroutine and read after done is closed.
+	lost error
+}
+
+func (j *Job) keepLock(ctx context.Context, lock Lock, onLost context.CancelFunc) *heldLock {
+	h := &heldLock{lock: lock, stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(h.done)
+		ticker := time.NewTicker(j.lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				err := lock.Renew(ctx, j.lockTTL)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, ErrLockLost) {
+					j.logger.Warnw("error renewing job lock", "key", j.lockKey, "error", err)
+					continue
+				}
+				h.lost = err
+				j.logger.Errorw("job lock lost, cancelling job", "key", j.lockKey)
+				onLost()
+				return
+			}
+		}
+	}()
+	return h
+}
+
+// release stops renewal and releases the lock, returning the error that lost it, if any.
+func (h *heldLock) release(ctx context.Context) error {
+	close(h.stop)
+	<-h.done
+	return errors.Join(h.lost, h.lock.Release(ctx))
+}
+
+// MemoryLocker is a Locker for jobs within a single process, such as several schedulers
+// sharing jobs or tests of locking behavior.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryLockEntry
+}
+
+type memoryLockEntry struct {
+	owner     string
+	expiresAt time.Time
+}
+
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]memoryLockEntry)}
+}
+
+func (m *MemoryLocker) Acquire(_ context.Context, key string, ttl time.Duration) (Lock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if entry, ok := m.locks[key]; ok && now.Before(entry.expiresAt) {
+		return nil, ErrLockHeld
+	}
+	owner := uuid.NewString()
+	m.locks[key] = memoryLockEntry{owner: owner, expiresAt: now.Add(ttl)}
+	return &memoryLock{locker: m, key: key, owner: owner}, nil
+}
+
+type memoryLock struct {
+	locker *MemoryLocker
+	key    string
+	owner  string
+}
+
+func (l *memoryLock) Renew(_ context.Context, ttl time.Duration) error {
+	m := l.locker
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	entry, ok := m.locks[l.key]
+	if !ok || entry.owner != l.owner || !now.Before(entry.expiresAt) {
+		return ErrLockLost
+	}
+	entry.expiresAt = now.Add(ttl)
+	m.locks[l.key] = entry
+	return nil
+}
+
+func (l *memoryLock) Release(context.Context) error {
+	m := l.locker
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.locks[l.key]; ok && entry.owner == l.owner {
+		delete(m.locks, l.key)
+	}
+	return nil
+}
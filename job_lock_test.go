@@ -0,0 +1,240 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	ctx := context.Background()
+	locker := ezutil.NewMemoryLocker()
+
+	lock, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+
+	_, err = locker.Acquire(ctx, "report", time.Minute)
+	assert.ErrorIs(t, err, ezutil.ErrLockHeld)
+
+	other, err := locker.Acquire(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, lock.Renew(ctx, time.Minute))
+	require.NoError(t, lock.Release(ctx))
+	assert.ErrorIs(t, lock.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+
+	again, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, again.Release(ctx))
+}
+
+func TestMemoryLocker_Expiry(t *testing.T) {
+	ctx := context.Background()
+	locker := ezutil.NewMemoryLocker()
+
+	stale, err := locker.Acquire(ctx, "report", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	fresh, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	assert.ErrorIs(t, stale.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+
+	// Releasing the stale lock must not release the new holder's lock.
+	require.NoError(t, stale.Release(ctx))
+	_, err = locker.Acquire(ctx, "report", time.Minute)
+	assert.ErrorIs(t, err, ezutil.ErrLockHeld)
+	require.NoError(t, fresh.Release(ctx))
+}
+
+func TestJob_WithLock_NilLocker(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewJob(&MockLogger{}, func() error { return nil }).WithLock(nil, "key", time.Second)
+	})
+}
+
+func TestJob_WithLock_RunsOnOneReplica(t *testing.T) {
+	locker := ezutil.NewMemoryLocker()
+	release := make(chan struct{})
+	var running atomic.Int32
+
+	newReplica := func(logger ezutil.Logger) *ezutil.Job {
+		return ezutil.NewJob(logger, func() error {
+			running.Add(1)
+			<-release
+			return nil
+		}).WithLock(locker, "nightly-report", time.Minute)
+	}
+
+	first := ezutil.NewTestLogger()
+	results := make(chan ezutil.JobResult, 1)
+	go func() { results <- newReplica(first).Execute(context.Background()) }()
+	require.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, time.Millisecond)
+
+	second := ezutil.NewTestLogger()
+	skipped := newReplica(second).Execute(context.Background())
+	assert.True(t, skipped.Skipped)
+	assert.NoError(t, skipped.Err())
+	second.AssertLogged(t, ezutil.LevelInfo, "skipping run")
+	second.AssertNotLogged(t, ezutil.LevelInfo, "running job...")
+
+	close(release)
+	result := <-results
+	assert.False(t, result.Skipped)
+	assert.NoError(t, result.Err())
+	assert.Equal(t, int32(1), running.Load())
+
+	// The lock is released after the run, so the next run can take it.
+	lock, err := locker.Acquire(context.Background(), "nightly-report", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(context.Background()))
+}
+
+func TestJob_WithLock_ReleasedAfterCleanup(t *testing.T) {
+	locker := ezutil.NewMemoryLocker()
+	var heldDuringCleanup error
+
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return errors.New("run failed") }).
+		WithLock(locker, "job", time.Minute).
+		WithCleanupFunc(func() error {
+			_, heldDuringCleanup = locker.Acquire(context.Background(), "job", time.Minute)
+			return nil
+		})
+
+	result := job.Execute(context.Background())
+
+	assert.ErrorIs(t, heldDuringCleanup, ezutil.ErrLockHeld)
+	assert.EqualError(t, result.Err(), "error running job: run failed")
+	lock, err := locker.Acquire(context.Background(), "job", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(context.Background()))
+}
+
+// losingLocker hands out locks that are lost on their first renewal.
+type losingLocker struct {
+	mu       sync.Mutex
+	released bool
+}
+
+func (l *losingLocker) Acquire(context.Context, string, time.Duration) (ezutil.Lock, error) {
+	return l, nil
+}
+
+func (l *losingLocker) Renew(context.Context, time.Duration) error {
+	return ezutil.ErrLockLost
+}
+
+func (l *losingLocker) Release(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.released = true
+	return nil
+}
+
+func TestJob_WithLock_LostCancelsRun(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	locker := &losingLocker{}
+
+	result := ezutil.NewJobContext(logger, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).WithLock(locker, "job", 6*time.Millisecond).Execute(context.Background())
+
+	assert.ErrorIs(t, result.RunErr, context.Canceled)
+	assert.ErrorIs(t, result.LockErr, ezutil.ErrLockLost)
+	assert.ErrorIs(t, result.Err(), ezutil.ErrLockLost)
+	assert.True(t, locker.released)
+	logger.AssertLogged(t, ezutil.LevelError, "job lock lost, cancelling job")
+}
+
+func TestJob_WithLock_LostWithSignalCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not supported on windows")
+	}
+	locker := &losingLocker{}
+	job := ezutil.NewJobContext(ezutil.NewTestLogger(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).WithLock(locker, "job", 6*time.Millisecond).WithSignalCancel(syscall.SIGHUP)
+
+	var result ezutil.JobResult
+	assert.NotPanics(t, func() { result = job.Execute(context.Background()) })
+	assert.ErrorIs(t, result.LockErr, ezutil.ErrLockLost)
+	assert.True(t, locker.released)
+}
+
+// contextLocker is a MemoryLocker whose renewals fail once their context is done,
+// like a locker backed by a database.
+type contextLocker struct {
+	*ezutil.MemoryLocker
+}
+
+func (l contextLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (ezutil.Lock, error) {
+	lock, err := l.MemoryLocker.Acquire(ctx, key, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return contextLock{lock}, nil
+}
+
+type contextLock struct {
+	ezutil.Lock
+}
+
+func (l contextLock) Renew(ctx context.Context, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return l.Lock.Renew(ctx, ttl)
+}
+
+func TestJob_WithLock_RenewedDuringCleanupAfterTimeout(t *testing.T) {
+	locker := contextLocker{ezutil.NewMemoryLocker()}
+	var heldDuringCleanup error
+
+	result := ezutil.NewJobContext(ezutil.NewTestLogger(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).WithLock(locker, "job", 30*time.Millisecond).
+		WithTimeout(time.Millisecond).
+		WithCleanupFunc(func() error {
+			time.Sleep(90 * time.Millisecond)
+			_, heldDuringCleanup = locker.Acquire(context.Background(), "job", time.Minute)
+			return nil
+		}).
+		Execute(context.Background())
+
+	assert.ErrorIs(t, result.RunErr, context.DeadlineExceeded)
+	assert.NoError(t, result.LockErr)
+	assert.ErrorIs(t, heldDuringCleanup, ezutil.ErrLockHeld)
+}
+
+// failingLocker fails every acquisition.
+type failingLocker struct{}
+
+func (failingLocker) Acquire(context.Context, string, time.Duration) (ezutil.Lock, error) {
+	return nil, errors.New("database unavailable")
+}
+
+func TestJob_WithLock_AcquireError(t *testing.T) {
+	logger := &MockLogger{}
+	runCalled := false
+
+	ezutil.NewJob(logger, func() error {
+		runCalled = true
+		return nil
+	}).WithLock(failingLocker{}, "job", time.Minute).Run()
+
+	assert.False(t, runCalled)
+	assert.Equal(t, []string{"error locking job: database unavailable"}, logger.FatalfCalls)
+}
@@ -0,0 +1,72 @@
+package ezutil
+
+import "time"
+
+// Names of the metrics reported by a Job with WithMetrics. Every metric has a job label.
+const (
+	// JobRunsMetric counts finished executions by status: succeeded, failed or skipped.
+	JobRunsMetric = "ezutil_job_runs_total"
+	// JobFailuresMetric counts failed executions by the phase that failed: lock, setup, run or cleanup.
+	JobFailuresMetric = "ezutil_job_failures_total"
+	// JobDurationMetric is a histogram of the seconds spent in each phase: setup, run or cleanup.
+	JobDurationMetric = "ezutil_job_duration_seconds"
+	// JobRunningMetric is the number of executions in progress.
+	JobRunningMetric = "ezutil_job_running"
+	// JobLastSuccessMetric is the Unix time of the last successful execution, for alerting on
+	// jobs that have stopped succeeding.
+	JobLastSuccessMetric = "ezutil_job_last_success_timestamp_seconds"
+)
+
+// WithMetrics reports every execution of the job under name to metrics, so run counts,
+// failures and phase durations are available without instrumenting the job's functions.
+func (j *Job) WithMetrics(metrics Metrics, name string) *Job {
+	if metrics == nil {
+		panic("metrics cannot be nil")
+	}
+	j.metrics = metrics
+	j.metricsName = name
+	return j
+}
+
+// startMetrics marks an execution in progress and returns the function reporting its result.
+func (j *Job) startMetrics() func(result JobResult) {
+	if j.metrics == nil {
+		return func(JobResult) {}
+	}
+	job := Labels{"job": j.metricsName}
+	running := j.metrics.Gauge(JobRunningMetric, "Number of job executions in progress.", job)
+	running.Add(1)
+
+	return func(result JobResult) {
+		running.Add(-1)
+
+		status := result.status()
+		j.metrics.Counter(JobRunsMetric, "Number of finished job executions by status.",
+			Labels{"job": j.metricsName, "status": string(status)}).Inc()
+		if status == RunSucceeded {
+			j.metrics.Gauge(JobLastSuccessMetric, "Unix time of the last successful job execution.", job).
+				Set(float64(time.Now().UnixNano()) / float64(time.Second))
+		}
+
+		for _, phase := range []struct {
+			name    string
+			err     error
+			latency time.Duration
+			ran     bool
+		}{
+			{"lock", result.LockErr, 0, false},
+			{"setup", result.SetupErr, result.SetupLatency, j.setupFunc != nil && result.SetupAttempts > 0},
+			{"run", result.RunErr, result.Latency, result.Attempts > 0},
+			{"cleanup", result.CleanupErr, result.CleanupLatency, j.cleanupFunc != nil && result.Attempts > 0},
+		} {
+			if phase.err != nil {
+				j.metrics.Counter(JobFailuresMetric, "Number of failed job executions by phase.",
+					Labels{"job": j.metricsName, "phase": phase.name}).Inc()
+			}
+			if phase.ran {
+				j.metrics.Histogram(JobDurationMetric, "Seconds spent in each job phase.", nil,
+					Labels{"job": j.metricsName, "phase": phase.name}).Observe(phase.latency.Seconds())
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_WithMetrics(t *testing.T) {
+	ctx := context.Background()
+	registry := ezutil.NewMetricsRegistry()
+	calls := 0
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("timeout")
+		}
+		return nil
+	}).
+		WithSetupFunc(func() error { return nil }).
+		WithMetrics(registry, "report")
+
+	require.Error(t, job.Execute(ctx).Err())
+	require.NoError(t, job.Execute(ctx).Err())
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	text := b.String()
+
+	assert.Contains(t, text, `ezutil_job_runs_total{job="report",status="failed"} 1`)
+	assert.Contains(t, text, `ezutil_job_runs_total{job="report",status="succeeded"} 1`)
+	assert.Contains(t, text, `ezutil_job_failures_total{job="report",phase="run"} 1`)
+	assert.Contains(t, text, `ezutil_job_duration_seconds_count{job="report",phase="run"} 2`)
+	assert.Contains(t, text, `ezutil_job_duration_seconds_count{job="report",phase="setup"} 2`)
+	assert.NotContains(t, text, `phase="cleanup"`)
+	assert.Contains(t, text, `ezutil_job_running{job="report"} 0`)
+	assert.Contains(t, text, `ezutil_job_last_success_timestamp_seconds{job="report"}`)
+}
+
+func TestJob_WithMetrics_Skipped(t *testing.T) {
+	ctx := context.Background()
+	registry := ezutil.NewMetricsRegistry()
+	locker := ezutil.NewMemoryLocker()
+	lock, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	defer func() { _ = lock.Release(ctx) }()
+
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithLock(locker, "report", 0).
+		WithMetrics(registry, "report").
+		Execute(ctx)
+	require.True(t, result.Skipped)
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	assert.Contains(t, b.String(), `ezutil_job_runs_total{job="report",status="skipped"} 1`)
+	assert.NotContains(t, b.String(), "ezutil_job_duration_seconds")
+	assert.NotContains(t, b.String(), "ezutil_job_last_success_timestamp_seconds")
+}
+
+func TestJob_WithMetrics_NilMetrics(t *testing.T) {
+	assert.PanicsWithValue(t, "metrics cannot be nil", func() {
+		ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).WithMetrics(nil, "report")
+	})
+}
+
+func TestJob_WithMetricsAndRecorderKeepTheirNames(t *testing.T) {
+	ctx := context.Background()
+	registry := ezutil.NewMetricsRegistry()
+	recorder := ezutil.NewMemoryRunRecorder(0)
+
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithRecorder(recorder, "nightly-report").
+		WithMetrics(registry, "report")
+	require.NoError(t, job.Execute(ctx).Err())
+
+	runs, err := recorder.Runs(ctx, "nightly-report", 0)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	assert.Contains(t, b.String(), `ezutil_job_runs_total{job="report",status="succeeded"} 1`)
+}
@@ -0,0 +1,156 @@
+package ezutil
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunStatus is the outcome of a recorded job run.
+type RunStatus string
+
+const (
+	// RunRunning marks a run that has started and not finished. A run left in this state
+	// long after it started points to a process that died mid-run.
+	RunRunning   RunStatus = "running"
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	// RunSkipped marks a run that did not execute because another owner held the job's lock.
+	RunSkipped RunStatus = "skipped"
+)
+
+// RunRecord describes one execution of a job.
+type RunRecord struct {
+	ID         string
+	Job        string
+	Status     RunStatus
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Error is the text of JobResult.Err for failed runs.
+	Error    string
+	Attempts int
+	Latency  time.Duration
+}
+
+// RunRecorder stores job runs. Start is called when a run begins and Finish with the same
+// ID once it ends, so a dashboard can show runs in progress as well as finished ones.
+type RunRecorder interface {
+	Start(ctx context.Context, run RunRecord) error
+	Finish(ctx context.Context, run RunRecord) error
+}
+
+// RunHistory reads recorded runs. Comparing the StartedAt of a job's latest run with its
+// schedule shows whether the job has silently stopped running.
+type RunHistory interface {
+	// Runs returns up to limit runs of job, newest first. A limit of zero returns every run.
+	Runs(ctx context.Context, job string, limit int) ([]RunRecord, error)
+}
+
+// WithRecorder records every execution of the job under name with recorder.
+// Recording errors are logged and do not fail the job.
+func (j *Job) WithRecorder(recorder RunRecorder, name string) *Job {
+	if recorder == nil {
+		panic("recorder cannot be nil")
+	}
+	j.recorder = recorder
+	j.recordName = name
+	return j
+}
+
+// startRecord records the start of a run. It returns the record to finish, or nil when
+// the job has no recorder.
+func (j *Job) startRecord(ctx context.Context) *RunRecord {
+	if j.recorder == nil {
+		return nil
+	}
+	run := &RunRecord{
+		ID:        uuid.NewString(),
+		Job:       j.recordName,
+		Status:    RunRunning,
+		StartedAt: time.Now(),
+	}
+	if err := j.recorder.Start(ctx, *run); err != nil {
+		j.logger.Warnw("error recording job run start", "job", j.recordName, "run_id", run.ID, "error", err)
+	}
+	return run
+}
+
+func (j *Job) finishRecord(ctx context.Context, run *RunRecord, result JobResult) {
+	if run == nil {
+		return
+	}
+	run.FinishedAt = time.Now()
+	run.Attempts = result.Attempts
+	run.Latency = result.Latency
+	run.Status = result.status()
+	if err := result.Err(); err != nil {
+		run.Error = err.Error()
+	}
+	if err := j.recorder.Finish(ctx, *run); err != nil {
+		j.logger.Warnw("error recording job run finish", "job", j.recordName, "run_id", run.ID, "error", err)
+	}
+}
+
+// status is the RunStatus of a finished execution.
+func (r JobResult) status() RunStatus {
+	switch {
+	case r.Err() != nil:
+		return RunFailed
+	case r.Skipped:
+		return RunSkipped
+	default:
+		return RunSucceeded
+	}
+}
+
+// MemoryRunRecorder keeps job runs in memory. It implements RunRecorder and RunHistory.
+type MemoryRunRecorder struct {
+	maxRuns int
+
+	mu   sync.Mutex
+	runs map[string][]RunRecord
+}
+
+// NewMemoryRunRecorder returns a recorder keeping the latest maxRuns runs of each job,
+// or every run if maxRuns is zero.
+func NewMemoryRunRecorder(maxRuns int) *MemoryRunRecorder {
+	return &MemoryRunRecorder{maxRuns: maxRuns, runs: make(map[string][]RunRecord)}
+}
+
+func (m *MemoryRunRecorder) Start(_ context.Context, run RunRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := append(m.runs[run.Job], run)
+	if m.maxRuns > 0 && len(runs) > m.maxRuns {
+		runs = slices.Delete(runs, 0, len(runs)-m.maxRuns)
+	}
+	m.runs[run.Job] = runs
+	return nil
+}
+
+func (m *MemoryRunRecorder) Finish(_ context.Context, run RunRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := m.runs[run.Job]
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].ID == run.ID {
+			runs[i] = run
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *MemoryRunRecorder) Runs(_ context.Context, job string, limit int) ([]RunRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := slices.Clone(m.runs[job])
+	slices.Reverse(runs)
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
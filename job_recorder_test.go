@@ -0,0 +1,121 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRunRecorder(t *testing.T) {
+	ctx := context.Background()
+	recorder := ezutil.NewMemoryRunRecorder(2)
+	start := time.Date(2024, 3, 15, 2, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"a", "b", "c"} {
+		run := ezutil.RunRecord{ID: id, Job: "report", Status: ezutil.RunRunning, StartedAt: start.Add(time.Duration(i) * time.Hour)}
+		require.NoError(t, recorder.Start(ctx, run))
+	}
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "x", Job: "other"}))
+	require.NoError(t, recorder.Finish(ctx, ezutil.RunRecord{ID: "c", Job: "report", Status: ezutil.RunSucceeded}))
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "c", runs[0].ID)
+	assert.Equal(t, ezutil.RunSucceeded, runs[0].Status)
+	assert.Equal(t, "b", runs[1].ID)
+	assert.Equal(t, ezutil.RunRunning, runs[1].Status)
+
+	latest, err := recorder.Runs(ctx, "report", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []ezutil.RunRecord{runs[0]}, latest)
+
+	none, err := recorder.Runs(ctx, "missing", 0)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestJob_WithRecorder(t *testing.T) {
+	ctx := context.Background()
+	recorder := ezutil.NewMemoryRunRecorder(0)
+	calls := 0
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("timeout")
+		}
+		return nil
+	}).WithRecorder(recorder, "report")
+
+	failed := job.Execute(ctx)
+	succeeded := job.Execute(ctx)
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	assert.Equal(t, succeeded.RunID, runs[0].ID)
+	assert.Equal(t, ezutil.RunSucceeded, runs[0].Status)
+	assert.Empty(t, runs[0].Error)
+	assert.Equal(t, 1, runs[0].Attempts)
+	assert.False(t, runs[0].FinishedAt.Before(runs[0].StartedAt))
+
+	assert.Equal(t, failed.RunID, runs[1].ID)
+	assert.Equal(t, ezutil.RunFailed, runs[1].Status)
+	assert.Equal(t, "error running job: timeout", runs[1].Error)
+	assert.Equal(t, "report", runs[1].Job)
+}
+
+func TestJob_WithRecorder_Skipped(t *testing.T) {
+	ctx := context.Background()
+	recorder := ezutil.NewMemoryRunRecorder(0)
+	locker := ezutil.NewMemoryLocker()
+	lock, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	defer func() { _ = lock.Release(ctx) }()
+
+	ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithLock(locker, "report", time.Minute).
+		WithRecorder(recorder, "report").
+		Execute(ctx)
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, ezutil.RunSkipped, runs[0].Status)
+}
+
+// failingRecorder fails every call.
+type failingRecorder struct{}
+
+func (failingRecorder) Start(context.Context, ezutil.RunRecord) error {
+	return errors.New("db down")
+}
+
+func (failingRecorder) Finish(context.Context, ezutil.RunRecord) error {
+	return errors.New("db down")
+}
+
+func TestJob_WithRecorder_ErrorsDoNotFailJob(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+
+	err := ezutil.NewJob(logger, func() error { return nil }).
+		WithRecorder(failingRecorder{}, "report").
+		RunE()
+
+	assert.NoError(t, err)
+	assert.Len(t, logger.EntriesAt(ezutil.LevelWarn), 2)
+	logger.AssertLogged(t, ezutil.LevelWarn, "error recording job run start")
+	logger.AssertLogged(t, ezutil.LevelWarn, "error recording job run finish")
+}
+
+func TestJob_WithRecorder_NilRecorder(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewJob(&MockLogger{}, func() error { return nil }).WithRecorder(nil, "report")
+	})
+}
@@ -0,0 +1,99 @@
+package ezutil
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how a Job retries a failing function.
+// The zero value makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 1 mean 1.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each failed attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction in either direction, so 0.2
+	// waits between 80% and 120% of the computed backoff. It is clamped to [0, 1].
+	Jitter float64
+	// Retryable reports whether err is worth another attempt. Nil retries every error.
+	Retryable func(err error) bool
+}
+
+func (p RetryPolicy) attempts() int {
+	return max(p.MaxAttempts, 1)
+}
+
+// Backoff returns the wait before attempt, counted from 1, without jitter.
+// Attempt 2 waits InitialBackoff and each later attempt waits Multiplier times longer.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	backoff := float64(initial) * math.Pow(multiplier, float64(attempt-2))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	if backoff > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(backoff)
+}
+
+func (p RetryPolicy) jittered(attempt int) time.Duration {
+	backoff := p.Backoff(attempt)
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || backoff <= 0 {
+		return backoff
+	}
+	return time.Duration(float64(backoff) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// retry calls fn until it succeeds, returns an error the policy does not retry, or runs out
+// of attempts. Every failed attempt is logged. Waiting stops early when ctx is done.
+// It returns the number of attempts made.
+func (j *Job) retry(ctx context.Context, stage string, policy RetryPolicy, fn func(ctx context.Context) error) (int, error) {
+	maxAttempts := policy.attempts()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			j.logger.Infow("retrying job", "stage", stage, "attempt", attempt, "max_attempts", maxAttempts)
+		}
+		err := fn(ctx)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= maxAttempts || !policy.retryable(err) || ctx.Err() != nil {
+			if maxAttempts > 1 {
+				j.logger.Warnw("job attempt failed", "stage", stage, "attempt", attempt, "max_attempts", maxAttempts, "error", err)
+			}
+			return attempt, err
+		}
+		wait := policy.jittered(attempt + 1)
+		j.logger.Warnw("job attempt failed", "stage", stage, "attempt", attempt, "max_attempts", maxAttempts,
+			"retry_in", wait.String(), "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		}
+	}
+}
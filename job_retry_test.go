@@ -0,0 +1,121 @@
+package ezutil_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("connection reset")
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := ezutil.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, time.Duration(0), policy.Backoff(1))
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, 800*time.Millisecond, policy.Backoff(5))
+	assert.Equal(t, time.Second, policy.Backoff(6))
+	assert.Equal(t, time.Second, policy.Backoff(100))
+}
+
+func TestRetryPolicy_BackoffDefaults(t *testing.T) {
+	policy := ezutil.RetryPolicy{Multiplier: 3}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+}
+
+func TestJob_Run_RetrySucceeds(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	calls := 0
+	job := ezutil.NewJob(logger, func() error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	}).WithRetry(ezutil.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, Jitter: 0.5})
+
+	job.Run()
+
+	assert.Equal(t, 3, calls)
+	failures := logger.EntriesAt(ezutil.LevelWarn)
+	require.Len(t, failures, 2)
+	attempt, _ := failures[1].Field("attempt")
+	assert.Equal(t, 2, attempt)
+	stage, _ := failures[0].Field("stage")
+	assert.Equal(t, "run", stage)
+	logger.AssertLogged(t, ezutil.LevelInfo, "retrying job")
+	logger.AssertLogged(t, ezutil.LevelInfo, "after 3 attempts")
+	assert.Empty(t, logger.EntriesAt(ezutil.LevelFatal))
+}
+
+func TestJob_Run_RetryExhausted(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	calls, cleanups := 0, 0
+	job := ezutil.NewJob(logger, func() error {
+		calls++
+		return errTransient
+	}).WithRetry(ezutil.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}).
+		WithCleanupFunc(func() error {
+			cleanups++
+			return nil
+		})
+
+	job.Run()
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 1, cleanups)
+	assert.Len(t, logger.EntriesAt(ezutil.LevelWarn), 3)
+	logger.AssertLogged(t, ezutil.LevelFatal, "error running job: connection reset")
+}
+
+func TestJob_Run_RetryNotRetryable(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	errInvalid := errors.New("invalid input")
+	calls := 0
+	job := ezutil.NewJob(logger, func() error {
+		calls++
+		return errInvalid
+	}).WithRetry(ezutil.RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		Retryable:      func(err error) bool { return errors.Is(err, errTransient) },
+	})
+
+	job.Run()
+
+	assert.Equal(t, 1, calls)
+	logger.AssertLogged(t, ezutil.LevelFatal, "error running job: invalid input")
+}
+
+func TestJob_Run_SetupRetry(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	setups := 0
+	runCalled := false
+	job := ezutil.NewJob(logger, func() error {
+		runCalled = true
+		return nil
+	}).WithSetupFunc(func() error {
+		setups++
+		if setups == 1 {
+			return errTransient
+		}
+		return nil
+	}).WithSetupRetry(ezutil.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	job.Run()
+
+	assert.Equal(t, 2, setups)
+	assert.True(t, runCalled)
+	failure := logger.EntriesAt(ezutil.LevelWarn)[0]
+	stage, _ := failure.Field("stage")
+	assert.Equal(t, "setup", stage)
+	retryIn, _ := failure.Field("retry_in")
+	assert.Equal(t, "1ms", retryIn)
+}
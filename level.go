@@ -0,0 +1,156 @@
+package ezutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+// Level is the severity of a log entry. Loggers write entries at or above their minimum level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+	LevelFatal: "FATAL",
+}
+
+// String returns the upper-case name of the level, as written in log output.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// ParseLevel converts a case-insensitive level name such as "debug" or "WARN" into a Level.
+// "warning" is accepted as an alias for WARN. Useful for reading the level from environment variables.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARN", "WARNING":
+		return LevelWarn, nil
+	case "ERROR":
+		return LevelError, nil
+	case "FATAL":
+		return LevelFatal, nil
+	default:
+		return LevelInfo, ungerr.Unknownf("unknown log level: %q", s)
+	}
+}
+
+// LevelController is implemented by loggers whose minimum level can be read and changed at runtime.
+// Loggers returned by NewSimpleLogger implement it, and their children share the level.
+type LevelController interface {
+	Level() Level
+	SetLevel(level Level)
+}
+
+// NamespaceLevelController is implemented by loggers that support per-namespace minimum levels,
+// such as those returned by NewSimpleLogger. An override applies to the prefix and every namespace
+// nested below it, the longest matching prefix wins, and it takes precedence over the logger level,
+// so DEBUG can be enabled for "app.billing" alone.
+type NamespaceLevelController interface {
+	SetNamespaceLevel(prefix string, level Level)
+	ClearNamespaceLevel(prefix string)
+}
+
+// NewLevelHandler returns an http.Handler for inspecting and changing the level of c while the
+// program runs. GET responds with {"level":"INFO"}; PUT or POST sets the level from a JSON body of
+// the same shape or from the "level" query parameter.
+func NewLevelHandler(c LevelController) http.Handler {
+	if c == nil {
+		panic("level controller cannot be nil")
+	}
+	return &levelHandler{c}
+}
+
+type levelHandler struct {
+	controller LevelController
+}
+
+type levelPayload struct {
+	Level *Level `json:"level,omitempty"`
+	Error any    `json:"error,omitempty"`
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		level, err := levelFromRequest(r)
+		if err != nil {
+			writeLevelResponse(w, err.HttpStatus(), levelPayload{Error: err.Details()})
+			return
+		}
+		h.controller.SetLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeLevelResponse(w, http.StatusMethodNotAllowed, levelPayload{Error: "method not allowed"})
+		return
+	}
+
+	current := h.controller.Level()
+	writeLevelResponse(w, http.StatusOK, levelPayload{Level: &current})
+}
+
+func levelFromRequest(r *http.Request) (Level, ungerr.AppError) {
+	if value := r.URL.Query().Get("level"); value != "" {
+		return parseLevelParam(value)
+	}
+
+	var payload struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return LevelInfo, ungerr.BadRequestError("request body must be JSON like {\"level\":\"debug\"}")
+	}
+	return parseLevelParam(payload.Level)
+}
+
+// parseLevelParam reports a bad request rather than the ParseLevel error,
+// which carries source locations that should not reach HTTP clients.
+func parseLevelParam(value string) (Level, ungerr.AppError) {
+	level, err := ParseLevel(value)
+	if err != nil {
+		return LevelInfo, ungerr.BadRequestError("unknown log level: " + value)
+	}
+	return level, nil
+}
+
+func writeLevelResponse(w http.ResponseWriter, status int, payload levelPayload) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
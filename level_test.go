@@ -0,0 +1,145 @@
+package ezutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "DEBUG", ezutil.LevelDebug.String())
+	assert.Equal(t, "INFO", ezutil.LevelInfo.String())
+	assert.Equal(t, "WARN", ezutil.LevelWarn.String())
+	assert.Equal(t, "ERROR", ezutil.LevelError.String())
+	assert.Equal(t, "FATAL", ezutil.LevelFatal.String())
+	assert.Equal(t, "LEVEL(9)", ezutil.Level(9).String())
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ezutil.Level
+	}{
+		{"debug", ezutil.LevelDebug},
+		{"INFO", ezutil.LevelInfo},
+		{" Warn ", ezutil.LevelWarn},
+		{"warning", ezutil.LevelWarn},
+		{"error", ezutil.LevelError},
+		{"FATAL", ezutil.LevelFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ezutil.ParseLevel(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestParseLevel_Invalid(t *testing.T) {
+	_, err := ezutil.ParseLevel("verbose")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown log level: "verbose"`)
+}
+
+func TestLevel_TextMarshaling(t *testing.T) {
+	var cfg struct {
+		Level ezutil.Level `json:"level"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"level":"warn"}`), &cfg))
+	assert.Equal(t, ezutil.LevelWarn, cfg.Level)
+
+	data, err := json.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"level":"WARN"}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"level":"loud"}`), &cfg))
+}
+
+func TestSimpleLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&buf))
+	child := logger.With("k", "v")
+
+	controller, ok := logger.(ezutil.LevelController)
+	assert.True(t, ok)
+	assert.Equal(t, ezutil.LevelInfo, controller.Level())
+
+	child.Debug("hidden")
+	controller.SetLevel(ezutil.LevelDebug)
+	child.Debug("shown")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "shown k=v")
+	assert.Equal(t, ezutil.LevelDebug, child.(ezutil.LevelController).Level())
+}
+
+func TestNewLevelHandler_NilController(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewLevelHandler(nil)
+	})
+}
+
+func TestLevelHandler(t *testing.T) {
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithLevel(ezutil.LevelInfo))
+	controller := logger.(ezutil.LevelController)
+	handler := ezutil.NewLevelHandler(controller)
+
+	t.Run("GET returns the current level", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log/level", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"level":"INFO"}`, rec.Body.String())
+	})
+
+	t.Run("PUT with JSON body sets the level", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"debug"}`)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"level":"DEBUG"}`, rec.Body.String())
+		assert.Equal(t, ezutil.LevelDebug, controller.Level())
+	})
+
+	t.Run("POST with query parameter sets the level", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/log/level?level=error", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, ezutil.LevelError, controller.Level())
+	})
+
+	t.Run("invalid level is rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"loud"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"error":"unknown log level: loud"}`, rec.Body.String())
+		assert.Equal(t, ezutil.LevelError, controller.Level())
+	})
+
+	t.Run("malformed body is rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`level=debug`)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("other methods are not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/log/level", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, PUT, POST", rec.Header().Get("Allow"))
+	})
+}
@@ -1,6 +1,10 @@
 package ezutil
 
 import (
+	"io"
+	"reflect"
+	"sync/atomic"
+
 	"github.com/itsLeonB/ezutil/v2/internal"
 	"github.com/pressly/goose/v3"
 )
@@ -17,13 +21,197 @@ type Logger interface {
 	Errorf(format string, args ...any)
 	Fatalf(format string, args ...any)
 
+	// With returns a logger that attaches the given alternating key-value pairs to every entry.
+	With(fields ...any) Logger
+	Debugw(msg string, fields ...any)
+	Infow(msg string, fields ...any)
+	Warnw(msg string, fields ...any)
+	Errorw(msg string, fields ...any)
+	Fatalw(msg string, fields ...any)
+
+	// Named returns a logger whose namespace has name appended with a dot,
+	// so Named("billing").Named("stripe") on "app" logs as "app.billing.stripe".
+	Named(name string) Logger
+
 	goose.Logger
 }
 
-func NewSimpleLogger(namespace string, useColor bool, minLevel int) Logger {
-	return &internal.SimpleLogger{
+// Flusher is implemented by loggers that buffer entries, such as a SimpleLogger created
+// with WithAsync. Job.Run and the fatal methods flush before exiting.
+type Flusher interface {
+	Flush() error
+}
+
+// DropCounter is implemented by loggers that can discard entries, such as a SimpleLogger
+// created with WithAsync and a dropping OverflowPolicy, or a SampledLogger.
+type DropCounter interface {
+	// Dropped returns the total number of entries discarded so far.
+	Dropped() uint64
+}
+
+// flushLogger flushes logger when it buffers entries.
+func flushLogger(logger Logger) error {
+	if f, ok := logger.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// OverflowPolicy decides what an async logger does when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the logging call wait until the buffer has room.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued entry to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the entry being logged.
+	OverflowDropNewest
+)
+
+// LoggerOption configures a logger created by NewSimpleLogger.
+type LoggerOption func(*internal.SimpleLogger)
+
+// WithJSONOutput makes the logger write one JSON object per line with the time, level, namespace,
+// msg and any fields as keys. A field named like one of the entry's own keys is written with a
+// "fields." prefix, such as "fields.msg". Colors are ignored in this mode.
+func WithJSONOutput() LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.Encoding = internal.EncodingJSON
+	}
+}
+
+// WithWriter sends log output to w instead of os.Stdout. Writes to w are serialized by a lock
+// shared with loggers derived through With and Named, so w need not be safe for concurrent use.
+func WithWriter(w io.Writer) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.Writer = syncWriter(l.ErrWriter, w)
+	}
+}
+
+// WithErrorWriter sends WARN, ERROR and FATAL entries to w, leaving lower levels on the main writer.
+// Writes to w are serialized like those of WithWriter.
+func WithErrorWriter(w io.Writer) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.ErrWriter = syncWriter(l.Writer, w)
+	}
+}
+
+// syncWriter wraps w for a logger, reusing other's lock when it already wraps w so that the
+// main and error writers never write to the same destination concurrently.
+func syncWriter(other, w io.Writer) io.Writer {
+	if sw, ok := other.(*internal.SyncWriter); ok && reflect.TypeOf(w).Comparable() && sw.W == w {
+		return sw
+	}
+	return internal.NewSyncWriter(w)
+}
+
+// WithExitFunc replaces os.Exit as the function called after a fatal entry is written.
+// Pass a function that panics or records the exit code to observe fatal events in tests
+// or to let an embedding program run its own shutdown. If fn returns, the Fatal call returns too.
+func WithExitFunc(fn func(code int)) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.ExitFunc = fn
+	}
+}
+
+// WithCaller adds the file:line and function of the logging call to every entry,
+// as caller and func fields.
+func WithCaller() LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.AddCaller = true
+	}
+}
+
+// WithStacktrace attaches a stack trace to entries at level and above, typically LevelError.
+// When an argument or field is an ungerr error that already records where it was created,
+// that trace is written instead of the logging call's stack.
+func WithStacktrace(level Level) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.AddStacktrace = true
+		l.StacktraceLevel = int(level)
+	}
+}
+
+// WithAsync queues formatted entries in a buffer of bufferSize entries that a background goroutine
+// writes out, taking I/O off the logging call. policy decides what happens when the buffer is full.
+// The logger then implements Flusher and io.Closer; flush or close it before the program exits.
+// The fatal methods and Job.Run flush on their own. It also implements DropCounter, reporting
+// the entries discarded by policy across the loggers derived with With and Named.
+func WithAsync(bufferSize int, policy OverflowPolicy) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.Async = internal.NewAsyncWriter(bufferSize, internal.OverflowPolicy(policy))
+	}
+}
+
+// WithLevel sets the minimum level, overriding the minLevel passed to NewSimpleLogger.
+func WithLevel(level Level) LoggerOption {
+	return func(l *internal.SimpleLogger) {
+		l.MinLevel = int(level)
+		l.SetMinLevel(int(level))
+	}
+}
+
+// NewSimpleLogger returns a logger writing entries at or above minLevel, from 0 (debug) to
+// 4 (fatal), to stdout. Prefer WithLevel for a typed Level such as LevelInfo.
+func NewSimpleLogger(namespace string, useColor bool, minLevel int, opts ...LoggerOption) Logger {
+	levelVar := new(atomic.Int32)
+	levelVar.Store(int32(minLevel))
+	logger := &internal.SimpleLogger{
 		Namespace: namespace,
 		UseColor:  useColor,
 		MinLevel:  minLevel,
+		LevelVar:  levelVar,
+
+		NamespaceLevels: &internal.NamespaceLevels{},
 	}
+	for _, opt := range opts {
+		opt(logger)
+	}
+	return &simpleLogger{logger}
+}
+
+func init() {
+	// Wrappers between the caller and internal.SimpleLogger; WithCaller and WithStacktrace
+	// skip their frames. Register new wrapper types here.
+	internal.SkipCallerFuncs(
+		receiver[simpleLogger](),
+		receiver[redactingLogger](),
+		receiver[sampledLogger](),
+		receiver[samplerState](),
+		receiver[teeLogger](),
+		receiver[slogLogger](),
+		receiver[slogHandler](),
+	)
+}
+
+// receiver returns the name prefix of T's pointer methods, such as "pkg.(*teeLogger)".
+func receiver[T any]() string {
+	t := reflect.TypeFor[T]()
+	return t.PkgPath() + ".(*" + t.Name() + ")"
+}
+
+// simpleLogger adapts internal.SimpleLogger so that derived loggers satisfy Logger.
+type simpleLogger struct {
+	*internal.SimpleLogger
+}
+
+func (l *simpleLogger) With(fields ...any) Logger {
+	return &simpleLogger{l.SimpleLogger.With(fields...)}
+}
+
+func (l *simpleLogger) Named(name string) Logger {
+	return &simpleLogger{l.SimpleLogger.Named(name)}
+}
+
+func (l *simpleLogger) SetNamespaceLevel(prefix string, level Level) {
+	l.SimpleLogger.SetNamespaceLevel(prefix, int(level))
+}
+
+func (l *simpleLogger) Level() Level {
+	return Level(l.CurrentLevel())
+}
+
+func (l *simpleLogger) SetLevel(level Level) {
+	l.SetMinLevel(int(level))
 }
@@ -0,0 +1,46 @@
+package ezutil
+
+import (
+	"context"
+	"slices"
+)
+
+type loggerContextKey struct{}
+
+type fieldsContextKey struct{}
+
+var defaultLogger = NewSimpleLogger("app", false, int(LevelInfo))
+
+// ContextWithLogger returns a copy of ctx that carries logger.
+// Store the base logger; fields added with ContextWithFields are applied when it is retrieved.
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// ContextWithFields returns a copy of ctx carrying the given key-value pairs in addition to
+// any already present, such as a request ID, trace ID or tenant ID.
+func ContextWithFields(ctx context.Context, fields ...any) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsContextKey{}, append(slices.Clip(FieldsFromContext(ctx)), fields...))
+}
+
+// FieldsFromContext returns the key-value pairs added with ContextWithFields, or nil.
+func FieldsFromContext(ctx context.Context) []any {
+	fields, _ := ctx.Value(fieldsContextKey{}).([]any)
+	return fields
+}
+
+// LoggerFromContext returns the logger stored in ctx with the context fields attached.
+// When no logger was stored, an INFO-level SimpleLogger writing to stdout is used.
+func LoggerFromContext(ctx context.Context) Logger {
+	logger, ok := ctx.Value(loggerContextKey{}).(Logger)
+	if !ok {
+		logger = defaultLogger
+	}
+	if fields := FieldsFromContext(ctx); len(fields) > 0 {
+		return logger.With(fields...)
+	}
+	return logger
+}
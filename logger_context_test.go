@@ -0,0 +1,65 @@
+package ezutil_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerFromContext_Default(t *testing.T) {
+	logger := ezutil.LoggerFromContext(context.Background())
+	assert.NotNil(t, logger)
+}
+
+func TestLoggerFromContext_StoredLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := ezutil.NewSimpleLogger("CTX", false, 0, ezutil.WithWriter(&buf))
+
+	ctx := ezutil.ContextWithLogger(context.Background(), base)
+	ezutil.LoggerFromContext(ctx).Info("plain")
+
+	assert.Contains(t, buf.String(), "[CTX INFO] plain\n")
+}
+
+func TestLoggerFromContext_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	base := ezutil.NewSimpleLogger("CTX", false, 0, ezutil.WithWriter(&buf))
+
+	ctx := ezutil.ContextWithFields(context.Background(), "request_id", "r-1")
+	ctx = ezutil.ContextWithLogger(ctx, base)
+	ctx = ezutil.ContextWithFields(ctx, "tenant_id", "t-1")
+
+	ezutil.LoggerFromContext(ctx).Infow("handled", "status", 200)
+
+	assert.Contains(t, buf.String(), "handled request_id=r-1 tenant_id=t-1 status=200")
+}
+
+func TestContextWithFields_DoesNotLeakToParent(t *testing.T) {
+	parent := ezutil.ContextWithFields(context.Background(), "a", 1)
+	left := ezutil.ContextWithFields(parent, "b", 2)
+	right := ezutil.ContextWithFields(parent, "c", 3)
+
+	assert.Equal(t, []any{"a", 1}, ezutil.FieldsFromContext(parent))
+	assert.Equal(t, []any{"a", 1, "b", 2}, ezutil.FieldsFromContext(left))
+	assert.Equal(t, []any{"a", 1, "c", 3}, ezutil.FieldsFromContext(right))
+}
+
+func TestContextWithFields_Empty(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, ezutil.ContextWithFields(ctx))
+	assert.Nil(t, ezutil.FieldsFromContext(ctx))
+}
+
+func TestSlogHandler_ContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ezutil.NewSlogHandler(ezutil.NewSimpleLogger("CTX", false, 0, ezutil.WithWriter(&buf))))
+
+	ctx := ezutil.ContextWithFields(context.Background(), "trace_id", "abc")
+	logger.InfoContext(ctx, "traced", "step", 1)
+
+	assert.Contains(t, buf.String(), "traced trace_id=abc step=1")
+}
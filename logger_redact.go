@@ -0,0 +1,409 @@
+package ezutil
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Redactor is implemented by types that provide a log-safe representation of themselves.
+// The redacting logger logs the result of Redacted in place of the value.
+type Redactor interface {
+	Redacted() any
+}
+
+// DefaultRedactedKeys are the field keys masked when RedactionConfig.Keys is nil.
+var DefaultRedactedKeys = []string{"password", "passwd", "secret", "token", "authorization", "api_key", "apikey", "cookie"}
+
+// DefaultRedactionMask replaces redacted values when RedactionConfig.Mask is empty.
+const DefaultRedactionMask = "[REDACTED]"
+
+var (
+	jwtPattern   = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	cardPattern  = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// RedactionConfig controls what NewRedactingLogger masks.
+type RedactionConfig struct {
+	// Keys are matched case-insensitively as substrings of field keys, so "token" also masks
+	// "access_token". They also mask "key=value", "key: value" and JSON "key":"value" pairs
+	// inside messages. Nil means DefaultRedactedKeys.
+	Keys []string
+	// Patterns are additional expressions masked wherever they match in messages and string values.
+	Patterns []*regexp.Regexp
+	// DisableBuiltinPatterns turns off masking of JWTs, card numbers passing the Luhn check and emails.
+	DisableBuiltinPatterns bool
+	// Mask replaces redacted values. Defaults to DefaultRedactionMask.
+	Mask string
+}
+
+type redactor struct {
+	keys     []string
+	keyValue *regexp.Regexp
+	patterns []*regexp.Regexp
+	builtin  bool
+	mask     string
+}
+
+// NewRedactingLogger wraps logger so that sensitive values are masked before they are written.
+// Arguments and field values implementing Redactor are replaced by their Redacted result,
+// fields whose keys match cfg.Keys are masked, as are exported struct fields and map entries
+// with matching names inside arguments and field values, so plain %v does not leak them, and
+// messages and field values other than booleans and numbers are scanned for the configured
+// keys and patterns. Loggers derived with With and Named keep redacting.
+func NewRedactingLogger(logger Logger, cfg RedactionConfig) Logger {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	keys := cfg.Keys
+	if keys == nil {
+		keys = DefaultRedactedKeys
+	}
+	r := &redactor{
+		patterns: cfg.Patterns,
+		builtin:  !cfg.DisableBuiltinPatterns,
+		mask:     cfg.Mask,
+	}
+	if r.mask == "" {
+		r.mask = DefaultRedactionMask
+	}
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		r.keys = append(r.keys, strings.ToLower(key))
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	if len(quoted) > 0 {
+		// An already masked value matches whole, so that rescanning sanitized text is a no-op.
+		r.keyValue = regexp.MustCompile(`(?i)(\b[\w-]*(?:` + strings.Join(quoted, "|") + `)[\w-]*"?\s*[:=]\s*"?(?:(?:Bearer|Basic)\s+)?)(` +
+			regexp.QuoteMeta(r.mask) + `|[^\s,;&"}\]]+)`)
+	}
+	return &redactingLogger{logger: logger, r: r}
+}
+
+func (r *redactor) sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// text masks key-value pairs and patterns inside s.
+func (r *redactor) text(s string) string {
+	if r.keyValue != nil {
+		s = r.keyValue.ReplaceAllString(s, "${1}"+r.mask)
+	}
+	if r.builtin {
+		s = jwtPattern.ReplaceAllString(s, r.mask)
+		s = cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+			if luhnValid(match) {
+				return r.mask
+			}
+			return match
+		})
+		s = emailPattern.ReplaceAllString(s, r.mask)
+	}
+	for _, p := range r.patterns {
+		s = p.ReplaceAllString(s, r.mask)
+	}
+	return s
+}
+
+func (r *redactor) args(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		out[i] = r.sanitize(arg)
+	}
+	return out
+}
+
+// sanitize returns a copy of v for fmt in which Redactor values, including nested ones, are
+// replaced by their Redacted result, and exported struct fields and string map keys matching
+// the configured keys are masked. v itself is returned when nothing needs redacting. Only a
+// top-level pointer is followed, as fmt prints nested pointers as addresses.
+func (r *redactor) sanitize(v any) any {
+	if red, ok := v.(Redactor); ok {
+		return red.Redacted()
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		elem, changed := r.sanitizeValue(rv.Elem())
+		if !changed {
+			return v
+		}
+		ptr := reflect.New(elem.Type())
+		ptr.Elem().Set(elem)
+		return ptr.Interface()
+	}
+	if sanitized, changed := r.sanitizeValue(rv); changed {
+		return sanitized.Interface()
+	}
+	return v
+}
+
+func (r *redactor) sanitizeValue(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() {
+		return v, false
+	}
+	nilPointer := (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()
+	if v.CanInterface() && !nilPointer {
+		if red, ok := v.Interface().(Redactor); ok {
+			return redactedValue(v.Type(), red.Redacted()), true
+		}
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := r.sanitizeValue(v.Elem())
+		if !changed {
+			return v, false
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(elem)
+		return out, true
+	case reflect.Struct:
+		var out reflect.Value
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			value, changed := r.maskedValue(field.Type), true
+			if !r.sensitiveKey(field.Name) {
+				value, changed = r.sanitizeValue(v.Field(i))
+			}
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.New(v.Type()).Elem()
+				out.Set(v)
+			}
+			out.Field(i).Set(value)
+		}
+		return out, out.IsValid()
+	case reflect.Map:
+		if v.IsNil() {
+			return v, false
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		changed := false
+		iter := v.MapRange()
+		for iter.Next() {
+			key, value := iter.Key(), iter.Value()
+			if key.Kind() == reflect.String && r.sensitiveKey(key.String()) {
+				value, changed = r.maskedValue(v.Type().Elem()), true
+			} else if sanitized, ok := r.sanitizeValue(value); ok {
+				value, changed = sanitized, true
+			}
+			out.SetMapIndex(key, value)
+		}
+		return out, changed
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		default:
+			return v, false
+		}
+		var out reflect.Value
+		for i := range v.Len() {
+			elem, changed := r.sanitizeValue(v.Index(i))
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				if v.Kind() == reflect.Slice {
+					out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+					reflect.Copy(out, v)
+				} else {
+					out = reflect.New(v.Type()).Elem()
+					out.Set(v)
+				}
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, out.IsValid()
+	}
+	return v, false
+}
+
+// maskedValue returns the mask as a value of type t, or the zero value when t cannot hold a string.
+func (r *redactor) maskedValue(t reflect.Type) reflect.Value {
+	out := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.String:
+		out.SetString(r.mask)
+	case reflect.TypeFor[string]().AssignableTo(t):
+		out.Set(reflect.ValueOf(r.mask))
+	}
+	return out
+}
+
+// redactedValue returns redacted as a value of type t, converting between types of the same
+// kind, or the zero value when it does not fit.
+func redactedValue(t reflect.Type, redacted any) reflect.Value {
+	out := reflect.New(t).Elem()
+	rv := reflect.ValueOf(redacted)
+	switch {
+	case !rv.IsValid():
+	case rv.Type().AssignableTo(t):
+		out.Set(rv)
+	case rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t):
+		out.Set(rv.Convert(t))
+	}
+	return out
+}
+
+func (r *redactor) fields(fields []any) []any {
+	out := make([]any, len(fields))
+	copy(out, fields)
+	for i := 0; i+1 < len(out); i += 2 {
+		if key, ok := out[i].(string); ok && r.sensitiveKey(key) {
+			out[i+1] = r.mask
+			continue
+		}
+		out[i+1] = r.value(out[i+1])
+	}
+	return out
+}
+
+// value redacts a field value. Booleans and numbers keep their types; every other value,
+// including structs and maps, is sanitized, rendered with %+v and scanned like a message so
+// that nested fields such as a request's password are masked too. Errors and Stringers go
+// through fmt, which prints a typed nil whose method would dereference it as "<nil>".
+func (r *redactor) value(v any) any {
+	if red, ok := v.(Redactor); ok {
+		v = red.Redacted()
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return r.text(val)
+	case error, fmt.Stringer:
+		return r.text(fmt.Sprint(val))
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return v
+	default:
+		return r.text(fmt.Sprintf("%+v", r.sanitize(v)))
+	}
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by card numbers.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
+
+type redactingLogger struct {
+	logger Logger
+	r      *redactor
+}
+
+func (l *redactingLogger) Flush() error {
+	return flushLogger(l.logger)
+}
+
+func (l *redactingLogger) withoutExit() (Logger, func(code int)) {
+	suppressor, ok := l.logger.(exitSuppressor)
+	if !ok {
+		return l, nil
+	}
+	quiet, exit := suppressor.withoutExit()
+	return &redactingLogger{logger: quiet, r: l.r}, exit
+}
+
+func (l *redactingLogger) sprintln(args []any) string {
+	return l.r.text(sprintln(l.r.args(args)...))
+}
+
+func (l *redactingLogger) sprintf(format string, args []any) string {
+	return l.r.text(fmt.Sprintf(format, l.r.args(args)...))
+}
+
+func (l *redactingLogger) Debug(args ...any) { l.logger.Debug(l.sprintln(args)) }
+func (l *redactingLogger) Info(args ...any)  { l.logger.Info(l.sprintln(args)) }
+func (l *redactingLogger) Warn(args ...any)  { l.logger.Warn(l.sprintln(args)) }
+func (l *redactingLogger) Error(args ...any) { l.logger.Error(l.sprintln(args)) }
+func (l *redactingLogger) Fatal(args ...any) { l.logger.Fatal(l.sprintln(args)) }
+
+func (l *redactingLogger) Debugf(format string, args ...any) {
+	l.logger.Debug(l.sprintf(format, args))
+}
+
+func (l *redactingLogger) Infof(format string, args ...any) {
+	l.logger.Info(l.sprintf(format, args))
+}
+
+func (l *redactingLogger) Warnf(format string, args ...any) {
+	l.logger.Warn(l.sprintf(format, args))
+}
+
+func (l *redactingLogger) Errorf(format string, args ...any) {
+	l.logger.Error(l.sprintf(format, args))
+}
+
+func (l *redactingLogger) Fatalf(format string, args ...any) {
+	l.logger.Fatal(l.sprintf(format, args))
+}
+
+func (l *redactingLogger) With(fields ...any) Logger {
+	return &redactingLogger{logger: l.logger.With(l.r.fields(fields)...), r: l.r}
+}
+
+func (l *redactingLogger) Named(name string) Logger {
+	return &redactingLogger{logger: l.logger.Named(name), r: l.r}
+}
+
+func (l *redactingLogger) Debugw(msg string, fields ...any) {
+	l.logger.Debugw(l.r.text(msg), l.r.fields(fields)...)
+}
+
+func (l *redactingLogger) Infow(msg string, fields ...any) {
+	l.logger.Infow(l.r.text(msg), l.r.fields(fields)...)
+}
+
+func (l *redactingLogger) Warnw(msg string, fields ...any) {
+	l.logger.Warnw(l.r.text(msg), l.r.fields(fields)...)
+}
+
+func (l *redactingLogger) Errorw(msg string, fields ...any) {
+	l.logger.Errorw(l.r.text(msg), l.r.fields(fields)...)
+}
+
+func (l *redactingLogger) Fatalw(msg string, fields ...any) {
+	l.logger.Fatalw(l.r.text(msg), l.r.fields(fields)...)
+}
+
+func (l *redactingLogger) Printf(format string, args ...any) {
+	l.logger.Printf("%s", l.sprintf(format, args))
+}
@@ -0,0 +1,298 @@
+package ezutil
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingConfig controls how NewSampledLogger thins out repeated entries.
+// Entries are grouped by level and message template: the format string for the f methods,
+// the message for the w methods, and the rendered message otherwise.
+type SamplingConfig struct {
+	// Interval is the window over which entries are counted. Defaults to one second.
+	Interval time.Duration
+	// First is the number of entries per group written in each interval before sampling starts.
+	First int
+	// Thereafter writes every Thereafter-th entry once First is reached. Zero drops the rest.
+	Thereafter int
+}
+
+// SampledLogger is a Logger that drops repeated entries and reports how many it dropped.
+type SampledLogger interface {
+	Logger
+	// Dropped returns the total number of entries dropped so far.
+	Dropped() uint64
+}
+
+// NewSampledLogger wraps logger so that hot loops cannot flood the output.
+// When an interval ends with entries dropped, a WARN entry with the level, message
+// and dropped count is written through logger for each affected group, even if nothing
+// is logged afterwards. Flush writes the counts of intervals still in progress.
+// Fatal entries are never dropped. Loggers derived with With and Named share the same counters.
+func NewSampledLogger(logger Logger, cfg SamplingConfig) SampledLogger {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	return &sampledLogger{
+		logger: logger,
+		state: &samplerState{
+			cfg:    cfg,
+			root:   logger,
+			groups: make(map[sampleKey]*sampleCounter),
+		},
+	}
+}
+
+type sampleKey struct {
+	level    Level
+	template string
+}
+
+type sampleCounter struct {
+	windowStart time.Time
+	seen        int
+	dropped     uint64
+}
+
+type droppedReport struct {
+	key     sampleKey
+	dropped uint64
+}
+
+type samplerState struct {
+	cfg SamplingConfig
+	// root is the logger given to NewSampledLogger, so that reports do not carry the
+	// fields or namespace of whichever derived logger dropped the entries.
+	root      Logger
+	mu        sync.Mutex
+	groups    map[sampleKey]*sampleCounter
+	lastSweep time.Time
+	// timer reports dropped entries once their interval ends; nil when none is pending.
+	timer   *time.Timer
+	dropped atomic.Uint64
+}
+
+// allow records an entry and reports whether it should be written.
+func (s *samplerState) allow(level Level, template string) bool {
+	s.mu.Lock()
+	now := time.Now()
+	var reports []droppedReport
+	if now.Sub(s.lastSweep) >= s.cfg.Interval {
+		s.lastSweep = now
+		reports = s.sweep(now, false)
+	}
+	ok := s.count(now, sampleKey{level, template})
+	s.mu.Unlock()
+
+	s.report(reports)
+	return ok
+}
+
+func (s *samplerState) count(now time.Time, key sampleKey) bool {
+	counter, ok := s.groups[key]
+	if !ok {
+		counter = &sampleCounter{windowStart: now}
+		s.groups[key] = counter
+	}
+
+	counter.seen++
+	if counter.seen <= s.cfg.First {
+		return true
+	}
+	if s.cfg.Thereafter > 0 && (counter.seen-s.cfg.First)%s.cfg.Thereafter == 0 {
+		return true
+	}
+	counter.dropped++
+	s.dropped.Add(1)
+	if s.timer == nil {
+		s.timer = time.AfterFunc(counter.windowStart.Add(s.cfg.Interval).Sub(now), s.onTimer)
+	}
+	return false
+}
+
+// sweep collects the dropped counts of groups whose interval has ended, or of every group
+// when all is set, and closes the ended intervals.
+func (s *samplerState) sweep(now time.Time, all bool) []droppedReport {
+	var reports []droppedReport
+	for key, counter := range s.groups {
+		ended := now.Sub(counter.windowStart) >= s.cfg.Interval
+		if !ended && !all {
+			continue
+		}
+		if counter.dropped > 0 {
+			reports = append(reports, droppedReport{key, counter.dropped})
+			counter.dropped = 0
+		}
+		if ended {
+			delete(s.groups, key)
+		}
+	}
+	return reports
+}
+
+// onTimer reports the groups whose interval has ended and schedules the next report
+// for groups still dropping entries.
+func (s *samplerState) onTimer() {
+	s.mu.Lock()
+	now := time.Now()
+	s.timer = nil
+	reports := s.sweep(now, false)
+	var next time.Time
+	for _, counter := range s.groups {
+		end := counter.windowStart.Add(s.cfg.Interval)
+		if counter.dropped > 0 && (next.IsZero() || end.Before(next)) {
+			next = end
+		}
+	}
+	if !next.IsZero() {
+		s.timer = time.AfterFunc(next.Sub(now), s.onTimer)
+	}
+	s.mu.Unlock()
+
+	s.report(reports)
+}
+
+// flush reports every pending dropped count, including intervals still in progress.
+func (s *samplerState) flush() {
+	s.mu.Lock()
+	reports := s.sweep(time.Now(), true)
+	s.mu.Unlock()
+
+	s.report(reports)
+}
+
+func (s *samplerState) report(reports []droppedReport) {
+	for _, r := range reports {
+		s.root.Warnw("dropped sampled log entries",
+			"level", r.key.level.String(),
+			"message", r.key.template,
+			"dropped", r.dropped,
+			"interval", s.cfg.Interval.String(),
+		)
+	}
+}
+
+type sampledLogger struct {
+	logger Logger
+	state  *samplerState
+}
+
+func (l *sampledLogger) Dropped() uint64 {
+	return l.state.dropped.Load()
+}
+
+// Flush writes the dropped counts of intervals in progress and flushes the wrapped logger.
+func (l *sampledLogger) Flush() error {
+	l.state.flush()
+	return flushLogger(l.logger)
+}
+
+func (l *sampledLogger) withoutExit() (Logger, func(code int)) {
+	suppressor, ok := l.logger.(exitSuppressor)
+	if !ok {
+		return l, nil
+	}
+	quiet, exit := suppressor.withoutExit()
+	return &sampledLogger{logger: quiet, state: l.state}, exit
+}
+
+func (l *sampledLogger) check(level Level, template string) bool {
+	return l.state.allow(level, template)
+}
+
+func (l *sampledLogger) Debug(args ...any) {
+	if l.check(LevelDebug, fmt.Sprint(args...)) {
+		l.logger.Debug(args...)
+	}
+}
+
+func (l *sampledLogger) Info(args ...any) {
+	if l.check(LevelInfo, fmt.Sprint(args...)) {
+		l.logger.Info(args...)
+	}
+}
+
+func (l *sampledLogger) Warn(args ...any) {
+	if l.check(LevelWarn, fmt.Sprint(args...)) {
+		l.logger.Warn(args...)
+	}
+}
+
+func (l *sampledLogger) Error(args ...any) {
+	if l.check(LevelError, fmt.Sprint(args...)) {
+		l.logger.Error(args...)
+	}
+}
+
+func (l *sampledLogger) Fatal(args ...any) { l.logger.Fatal(args...) }
+
+func (l *sampledLogger) Debugf(format string, args ...any) {
+	if l.check(LevelDebug, format) {
+		l.logger.Debugf(format, args...)
+	}
+}
+
+func (l *sampledLogger) Infof(format string, args ...any) {
+	if l.check(LevelInfo, format) {
+		l.logger.Infof(format, args...)
+	}
+}
+
+func (l *sampledLogger) Warnf(format string, args ...any) {
+	if l.check(LevelWarn, format) {
+		l.logger.Warnf(format, args...)
+	}
+}
+
+func (l *sampledLogger) Errorf(format string, args ...any) {
+	if l.check(LevelError, format) {
+		l.logger.Errorf(format, args...)
+	}
+}
+
+func (l *sampledLogger) Fatalf(format string, args ...any) { l.logger.Fatalf(format, args...) }
+
+func (l *sampledLogger) With(fields ...any) Logger {
+	return &sampledLogger{logger: l.logger.With(fields...), state: l.state}
+}
+
+func (l *sampledLogger) Named(name string) Logger {
+	return &sampledLogger{logger: l.logger.Named(name), state: l.state}
+}
+
+func (l *sampledLogger) Debugw(msg string, fields ...any) {
+	if l.check(LevelDebug, msg) {
+		l.logger.Debugw(msg, fields...)
+	}
+}
+
+func (l *sampledLogger) Infow(msg string, fields ...any) {
+	if l.check(LevelInfo, msg) {
+		l.logger.Infow(msg, fields...)
+	}
+}
+
+func (l *sampledLogger) Warnw(msg string, fields ...any) {
+	if l.check(LevelWarn, msg) {
+		l.logger.Warnw(msg, fields...)
+	}
+}
+
+func (l *sampledLogger) Errorw(msg string, fields ...any) {
+	if l.check(LevelError, msg) {
+		l.logger.Errorw(msg, fields...)
+	}
+}
+
+func (l *sampledLogger) Fatalw(msg string, fields ...any) { l.logger.Fatalw(msg, fields...) }
+
+func (l *sampledLogger) Printf(format string, args ...any) {
+	if l.check(LevelInfo, format) {
+		l.logger.Printf(format, args...)
+	}
+}
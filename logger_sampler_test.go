@@ -0,0 +1,186 @@
+package ezutil_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSampledTestLogger(cfg ezutil.SamplingConfig) (ezutil.SampledLogger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	base := ezutil.NewSimpleLogger("SAMPLE", false, 0, ezutil.WithWriter(&buf))
+	return ezutil.NewSampledLogger(base, cfg), &buf
+}
+
+func TestNewSampledLogger_NilLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewSampledLogger(nil, ezutil.SamplingConfig{})
+	})
+}
+
+func TestSampledLogger_FirstThenEveryMth(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: time.Hour, First: 3, Thereafter: 5})
+
+	for i := range 20 {
+		logger.Warnf("retrying item %d", i)
+	}
+
+	output := buf.String()
+	assert.Equal(t, 6, strings.Count(output, "retrying item"))
+	for _, i := range []string{"0", "1", "2", "7", "12", "17"} {
+		assert.Contains(t, output, "retrying item "+i+"\n")
+	}
+	assert.Equal(t, uint64(14), logger.Dropped())
+}
+
+func TestSampledLogger_ZeroThereafterDropsRest(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: time.Hour, First: 2})
+
+	for range 10 {
+		logger.Infow("tick", "k", "v")
+	}
+
+	assert.Equal(t, 2, strings.Count(buf.String(), "tick"))
+	assert.Equal(t, uint64(8), logger.Dropped())
+}
+
+func TestSampledLogger_GroupsByLevelAndTemplate(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: time.Hour, First: 1})
+
+	logger.Info("same")
+	logger.Info("same")
+	logger.Warn("same")
+	logger.Errorf("other %d", 1)
+	logger.Errorf("other %d", 2)
+	logger.Debugw("structured")
+	logger.With("k", "v").Debugw("structured")
+
+	output := buf.String()
+	assert.Equal(t, 1, strings.Count(output, "[SAMPLE INFO] same"))
+	assert.Equal(t, 1, strings.Count(output, "[SAMPLE WARN] same"))
+	assert.Contains(t, output, "other 1")
+	assert.NotContains(t, output, "other 2")
+	assert.Equal(t, 1, strings.Count(output, "structured"))
+	assert.Equal(t, uint64(3), logger.Dropped())
+}
+
+func TestSampledLogger_ReportsDroppedAfterInterval(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: 20 * time.Millisecond, First: 1})
+
+	for range 5 {
+		logger.Errorf("db timeout after %dms", 100)
+	}
+	time.Sleep(30 * time.Millisecond)
+	logger.Info("next window")
+
+	output := buf.String()
+	assert.Contains(t, output, `[SAMPLE WARN] dropped sampled log entries level=ERROR message="db timeout after %dms" dropped=4 interval=20ms`)
+	assert.Contains(t, output, "next window")
+
+	buf.Reset()
+	logger.Errorf("db timeout after %dms", 100)
+	assert.Contains(t, buf.String(), "db timeout after 100ms", "a new interval starts a fresh budget")
+}
+
+func TestSampledLogger_ReportsDroppedWhenLoggingStops(t *testing.T) {
+	recorder := ezutil.NewTestLogger()
+	logger := ezutil.NewSampledLogger(recorder, ezutil.SamplingConfig{Interval: 10 * time.Millisecond, First: 1})
+
+	child := logger.Named("worker").With("item", 7)
+	for range 5 {
+		child.Errorf("db timeout after %dms", 100)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.EntriesAt(ezutil.LevelWarn)) == 1
+	}, time.Second, time.Millisecond)
+	report := recorder.EntriesAt(ezutil.LevelWarn)[0]
+	assert.Equal(t, "dropped sampled log entries", report.Message)
+	assert.Empty(t, report.Namespace)
+	assert.Equal(t, []any{"level", "ERROR", "message", "db timeout after %dms", "dropped", uint64(4), "interval", "10ms"}, report.Fields)
+}
+
+func TestSampledLogger_FlushReportsPendingDrops(t *testing.T) {
+	recorder := ezutil.NewTestLogger()
+	logger := ezutil.NewSampledLogger(recorder, ezutil.SamplingConfig{Interval: time.Hour, First: 1})
+
+	for range 3 {
+		logger.With("k", "v").Infow("tick")
+	}
+	flusher, ok := logger.(ezutil.Flusher)
+	assert.True(t, ok)
+	assert.NoError(t, flusher.Flush())
+
+	recorder.AssertLogged(t, ezutil.LevelWarn, "dropped sampled log entries")
+	report := recorder.EntriesAt(ezutil.LevelWarn)[0]
+	assert.Equal(t, []any{"level", "INFO", "message", "tick", "dropped", uint64(2), "interval", "1h0m0s"}, report.Fields)
+
+	// Counts already reported are not repeated.
+	assert.NoError(t, flusher.Flush())
+	assert.Len(t, recorder.EntriesAt(ezutil.LevelWarn), 1)
+}
+
+func TestSampledLogger_FatalIsNeverDropped(t *testing.T) {
+	var buf bytes.Buffer
+	exits := 0
+	base := ezutil.NewSimpleLogger("SAMPLE", false, 0, ezutil.WithWriter(&buf), ezutil.WithExitFunc(func(int) { exits++ }))
+	logger := ezutil.NewSampledLogger(base, ezutil.SamplingConfig{Interval: time.Hour})
+
+	logger.Fatal("fatal")
+	logger.Fatalf("fatal %d", 1)
+	logger.Fatalw("fatal")
+
+	assert.Equal(t, 3, exits)
+	assert.Equal(t, uint64(0), logger.Dropped())
+}
+
+func TestSampledLogger_ChildrenShareCounters(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: time.Hour, First: 1})
+
+	logger.Named("a").Info("shared")
+	logger.With("k", "v").Info("shared")
+	logger.Printf("goose %s", "one")
+	logger.Printf("goose %s", "two")
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "shared"))
+	assert.Contains(t, buf.String(), "[SAMPLE.a INFO] shared")
+	assert.Contains(t, buf.String(), "goose one")
+	assert.NotContains(t, buf.String(), "goose two")
+}
+
+func TestSampledLogger_Concurrent(t *testing.T) {
+	logger, buf := newSampledTestLogger(ezutil.SamplingConfig{Interval: time.Hour, First: 10, Thereafter: 100})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				logger.Debug("hot loop")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 19, strings.Count(buf.String(), "hot loop"))
+	assert.Equal(t, uint64(981), logger.Dropped())
+}
+
+func TestSampledLogger_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	base := ezutil.NewSimpleLogger("SAMPLE", false, 0, ezutil.WithWriter(&buf), ezutil.WithAsync(4, ezutil.OverflowBlock))
+	logger := ezutil.NewSampledLogger(base, ezutil.SamplingConfig{First: 10})
+
+	logger.Info("buffered")
+
+	flusher, ok := logger.(ezutil.Flusher)
+	assert.True(t, ok)
+	assert.NoError(t, flusher.Flush())
+	assert.Contains(t, buf.String(), "buffered")
+}
@@ -0,0 +1,243 @@
+package ezutil
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+)
+
+// SlogLevelFatal is the slog level used for Fatal entries written through NewSlogLogger.
+const SlogLevelFatal = slog.LevelError + 4
+
+// levelEnabler is implemented by loggers that can report whether a level would be written.
+type levelEnabler interface {
+	Enabled(level int) bool
+}
+
+// NewSlogHandler returns an slog.Handler that writes records through logger.
+// Attributes become structured fields, groups are flattened into dotted keys,
+// and slog levels map to the nearest of Debug, Info, Warn and Error.
+// Fields added to the record's context with ContextWithFields are included.
+func NewSlogHandler(logger Logger) slog.Handler {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	return &slogHandler{logger: logger}
+}
+
+type slogHandler struct {
+	logger Logger
+	prefix string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if enabler, ok := h.logger.(levelEnabler); ok {
+		return enabler.Enabled(int(slogToLevel(level)))
+	}
+	return true
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	ctxFields := FieldsFromContext(ctx)
+	fields := make([]any, 0, len(ctxFields)+r.NumAttrs()*2)
+	fields = append(fields, ctxFields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	switch {
+	case r.Level >= slog.LevelError:
+		h.logger.Errorw(r.Message, fields...)
+	case r.Level >= slog.LevelWarn:
+		h.logger.Warnw(r.Message, fields...)
+	case r.Level >= slog.LevelInfo:
+		h.logger.Infow(r.Message, fields...)
+	default:
+		h.logger.Debugw(r.Message, fields...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := make([]any, 0, len(attrs)*2)
+	for _, a := range attrs {
+		fields = appendAttr(fields, h.prefix, a)
+	}
+	return &slogHandler{logger: h.logger.With(fields...), prefix: h.prefix}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, prefix: h.prefix + name + "."}
+}
+
+// appendAttr flattens a into key-value pairs, following the slog.Handler rules
+// for empty keys and groups.
+func appendAttr(fields []any, prefix string, a slog.Attr) []any {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	return append(fields, prefix+a.Key, a.Value.Any())
+}
+
+func slogToLevel(level slog.Level) Level {
+	switch {
+	case level >= SlogLevelFatal:
+		return LevelFatal
+	case level >= slog.LevelError:
+		return LevelError
+	case level >= slog.LevelWarn:
+		return LevelWarn
+	case level >= slog.LevelInfo:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
+
+// SlogLoggerOption configures a logger created by NewSlogLogger.
+type SlogLoggerOption func(*slogLogger)
+
+// WithSlogExitFunc replaces os.Exit as the function called after a fatal entry is written,
+// like WithExitFunc does for NewSimpleLogger.
+func WithSlogExitFunc(fn func(code int)) SlogLoggerOption {
+	return func(s *slogLogger) {
+		s.exitFunc = fn
+	}
+}
+
+// NewSlogLogger returns a Logger that writes through l, so code that takes a Logger,
+// such as Job or goose migrations, can share an slog pipeline.
+// Fatal methods log at SlogLevelFatal and then exit the program.
+func NewSlogLogger(l *slog.Logger, opts ...SlogLoggerOption) Logger {
+	if l == nil {
+		panic("slog logger cannot be nil")
+	}
+	logger := &slogLogger{l: l}
+	for _, opt := range opts {
+		opt(logger)
+	}
+	return logger
+}
+
+type slogLogger struct {
+	l         *slog.Logger
+	namespace string
+	exitFunc  func(code int)
+}
+
+func (s *slogLogger) exit() {
+	if s.exitFunc != nil {
+		s.exitFunc(1)
+		return
+	}
+	os.Exit(1)
+}
+
+func (s *slogLogger) withoutExit() (Logger, func(code int)) {
+	exit := s.exitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	return &slogLogger{l: s.l, namespace: s.namespace, exitFunc: func(int) {}}, exit
+}
+
+// log builds the record itself so that the source location points at the caller
+// of the exported method rather than at this adapter.
+func (s *slogLogger) log(level slog.Level, msg string, fields ...any) {
+	ctx := context.Background()
+	if !s.l.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	if s.namespace != "" {
+		r.AddAttrs(slog.String("namespace", s.namespace))
+	}
+	r.Add(fields...)
+	_ = s.l.Handler().Handle(ctx, r)
+}
+
+func sprintln(args ...any) string {
+	return strings.TrimRight(fmt.Sprintln(args...), "\n")
+}
+
+func (s *slogLogger) Debug(args ...any) { s.log(slog.LevelDebug, sprintln(args...)) }
+func (s *slogLogger) Info(args ...any)  { s.log(slog.LevelInfo, sprintln(args...)) }
+func (s *slogLogger) Warn(args ...any)  { s.log(slog.LevelWarn, sprintln(args...)) }
+func (s *slogLogger) Error(args ...any) { s.log(slog.LevelError, sprintln(args...)) }
+
+func (s *slogLogger) Fatal(args ...any) {
+	s.log(SlogLevelFatal, sprintln(args...))
+	s.exit()
+}
+
+func (s *slogLogger) Debugf(format string, args ...any) {
+	s.log(slog.LevelDebug, fmt.Sprintf(format, args...))
+}
+
+func (s *slogLogger) Infof(format string, args ...any) {
+	s.log(slog.LevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (s *slogLogger) Warnf(format string, args ...any) {
+	s.log(slog.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+func (s *slogLogger) Errorf(format string, args ...any) {
+	s.log(slog.LevelError, fmt.Sprintf(format, args...))
+}
+
+func (s *slogLogger) Fatalf(format string, args ...any) {
+	s.log(SlogLevelFatal, fmt.Sprintf(format, args...))
+	s.exit()
+}
+
+func (s *slogLogger) With(fields ...any) Logger {
+	return &slogLogger{l: s.l.With(fields...), namespace: s.namespace, exitFunc: s.exitFunc}
+}
+
+// Named records the dotted namespace as a "namespace" attribute on each entry.
+func (s *slogLogger) Named(name string) Logger {
+	return &slogLogger{l: s.l, namespace: internal.JoinNamespace(s.namespace, name), exitFunc: s.exitFunc}
+}
+
+func (s *slogLogger) Debugw(msg string, fields ...any) { s.log(slog.LevelDebug, msg, fields...) }
+func (s *slogLogger) Infow(msg string, fields ...any)  { s.log(slog.LevelInfo, msg, fields...) }
+func (s *slogLogger) Warnw(msg string, fields ...any)  { s.log(slog.LevelWarn, msg, fields...) }
+func (s *slogLogger) Errorw(msg string, fields ...any) { s.log(slog.LevelError, msg, fields...) }
+
+func (s *slogLogger) Fatalw(msg string, fields ...any) {
+	s.log(SlogLevelFatal, msg, fields...)
+	s.exit()
+}
+
+func (s *slogLogger) Print(args ...any)   { s.log(slog.LevelInfo, fmt.Sprint(args...)) }
+func (s *slogLogger) Println(args ...any) { s.log(slog.LevelInfo, sprintln(args...)) }
+
+func (s *slogLogger) Printf(format string, args ...any) {
+	s.log(slog.LevelInfo, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,139 @@
+package ezutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogHandler_NilLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewSlogHandler(nil)
+	})
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ezutil.NewSlogHandler(ezutil.NewSimpleLogger("SLOG", false, 0, ezutil.WithWriter(&buf))))
+
+	logger.Debug("debug msg")
+	logger.Info("info msg")
+	logger.Warn("warn msg")
+	logger.Error("error msg")
+
+	output := buf.String()
+	assert.Contains(t, output, "[SLOG DEBUG] debug msg")
+	assert.Contains(t, output, "[SLOG INFO] info msg")
+	assert.Contains(t, output, "[SLOG WARN] warn msg")
+	assert.Contains(t, output, "[SLOG ERROR] error msg")
+}
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	var buf bytes.Buffer
+	handler := ezutil.NewSlogHandler(ezutil.NewSimpleLogger("SLOG", false, 2, ezutil.WithWriter(&buf)))
+
+	assert.False(t, handler.Enabled(t.Context(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(t.Context(), slog.LevelWarn))
+
+	slog.New(handler).Info("hidden")
+	assert.Empty(t, buf.String())
+}
+
+func TestSlogHandler_AttrsAndGroups(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ezutil.NewSlogHandler(ezutil.NewSimpleLogger("SLOG", false, 0, ezutil.WithWriter(&buf))))
+
+	logger.With("service", "api").
+		WithGroup("http").
+		Info("request", "method", "GET", slog.Group("resp", "status", 200), slog.Attr{}, slog.Group("", "inline", true))
+
+	assert.Contains(t, buf.String(), "request service=api http.method=GET http.resp.status=200 http.inline=true")
+}
+
+func TestSlogHandler_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ezutil.NewSlogHandler(ezutil.NewSimpleLogger("SLOG", false, 0, ezutil.WithWriter(&buf), ezutil.WithJSONOutput())))
+
+	logger.Info("json", "count", 2)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "json", decoded["msg"])
+	assert.Equal(t, float64(2), decoded["count"])
+}
+
+func TestNewSlogLogger_NilLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewSlogLogger(nil)
+	})
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	logger.Debug("debug", 1)
+	logger.Info("info")
+	logger.Warnf("warn %d", 2)
+	logger.Errorw("error", "key", "value")
+	logger.With("request_id", "r-1").Infow("child")
+	logger.Printf("goose %s", "migration")
+
+	output := buf.String()
+	assert.Contains(t, output, `level=DEBUG msg="debug 1"`)
+	assert.Contains(t, output, "level=INFO msg=info")
+	assert.Contains(t, output, `level=WARN msg="warn 2"`)
+	assert.Contains(t, output, "level=ERROR msg=error key=value")
+	assert.Contains(t, output, "msg=child request_id=r-1")
+	assert.Contains(t, output, `msg="goose migration"`)
+}
+
+func TestSlogLogger_Source(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true})))
+
+	logger.Info("with source")
+
+	assert.Contains(t, buf.String(), "logger_slog_test.go")
+}
+
+func TestSlogLogger_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "shown")
+}
+
+func TestSlogLogger_WithSlogExitFunc(t *testing.T) {
+	var buf bytes.Buffer
+	var codes []int
+	logger := ezutil.NewSlogLogger(
+		slog.New(slog.NewTextHandler(&buf, nil)),
+		ezutil.WithSlogExitFunc(func(code int) { codes = append(codes, code) }),
+	)
+
+	logger.Fatal("fatal")
+	logger.With("k", "v").Fatalf("fatal %d", 2)
+	logger.Fatalw("fatal", "k", "v")
+
+	assert.Equal(t, []int{1, 1, 1}, codes)
+	assert.Equal(t, 3, strings.Count(buf.String(), "level=ERROR+4"))
+}
+
+func TestSlogLogger_Named(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	logger.Named("billing").Named("stripe").Infow("charged", "amount", 10)
+
+	assert.Contains(t, buf.String(), "msg=charged namespace=billing.stripe amount=10")
+}
@@ -0,0 +1,189 @@
+package ezutil
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// Sink is a destination for NewFanoutLogger with its own level filter and encoding.
+type Sink struct {
+	// Writer receives the entries. Nil means os.Stdout.
+	Writer io.Writer
+	// MinLevel is the lowest level written to this sink.
+	MinLevel Level
+	// JSON writes one JSON object per entry instead of text.
+	JSON bool
+	// UseColor colors the level in text output.
+	UseColor bool
+}
+
+// NewFanoutLogger returns a logger that writes every entry to each sink, such as colored text
+// on stdout and JSON in a file. opts are applied to every sink, so WithCaller or WithExitFunc
+// affect them all; the sink settings take precedence over WithWriter and WithJSONOutput.
+func NewFanoutLogger(namespace string, sinks []Sink, opts ...LoggerOption) Logger {
+	loggers := make([]Logger, len(sinks))
+	for i, sink := range sinks {
+		sinkOpts := append([]LoggerOption{}, opts...)
+		if sink.Writer != nil {
+			sinkOpts = append(sinkOpts, WithWriter(sink.Writer))
+		}
+		if sink.JSON {
+			sinkOpts = append(sinkOpts, WithJSONOutput())
+		}
+		loggers[i] = NewSimpleLogger(namespace, sink.UseColor, int(sink.MinLevel), sinkOpts...)
+	}
+	return NewTeeLogger(loggers...)
+}
+
+// NewTeeLogger returns a logger that dispatches every entry to each of loggers, which keep
+// their own level filters and encoders. With and Named apply to all of them.
+//
+// A fatal entry is written to every logger before the program exits once, through the exit
+// function of the first logger that would exit: one created by NewSimpleLogger or
+// NewSlogLogger, or a redacting, sampled or tee logger wrapping one.
+func NewTeeLogger(loggers ...Logger) Logger {
+	t := &teeLogger{loggers: make([]Logger, len(loggers))}
+	for i, logger := range loggers {
+		if logger == nil {
+			panic("logger cannot be nil")
+		}
+		suppressor, ok := logger.(exitSuppressor)
+		if !ok {
+			t.loggers[i] = logger
+			continue
+		}
+		quiet, exit := suppressor.withoutExit()
+		t.loggers[i] = quiet
+		if t.exit == nil {
+			t.exit = exit
+		}
+	}
+	return t
+}
+
+// exitSuppressor is implemented by loggers whose fatal methods exit the program.
+type exitSuppressor interface {
+	// withoutExit returns a copy of the logger whose fatal methods return after writing
+	// the entry, and the exit function the logger would have called, or nil if it does not exit.
+	withoutExit() (Logger, func(code int))
+}
+
+func (l *simpleLogger) withoutExit() (Logger, func(code int)) {
+	exit := l.ExitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	quiet := *l.SimpleLogger
+	quiet.ExitFunc = func(int) {}
+	return &simpleLogger{&quiet}, exit
+}
+
+type teeLogger struct {
+	loggers []Logger
+	// exit is nil when none of the loggers exits the program on its own.
+	exit func(code int)
+}
+
+func (t *teeLogger) withoutExit() (Logger, func(code int)) {
+	return &teeLogger{loggers: t.loggers}, t.exit
+}
+
+// Enabled reports whether any logger would write an entry at level.
+func (t *teeLogger) Enabled(level int) bool {
+	for _, l := range t.loggers {
+		enabler, ok := l.(levelEnabler)
+		if !ok || enabler.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *teeLogger) Flush() error {
+	var errs []error
+	for _, l := range t.loggers {
+		errs = append(errs, flushLogger(l))
+	}
+	return errors.Join(errs...)
+}
+
+func (t *teeLogger) each(fn func(Logger)) {
+	for _, l := range t.loggers {
+		fn(l)
+	}
+}
+
+func (t *teeLogger) fatal(fn func(Logger)) {
+	t.each(fn)
+	if t.exit != nil {
+		_ = t.Flush()
+		t.exit(1)
+	}
+}
+
+func (t *teeLogger) Debug(args ...any) { t.each(func(l Logger) { l.Debug(args...) }) }
+func (t *teeLogger) Info(args ...any)  { t.each(func(l Logger) { l.Info(args...) }) }
+func (t *teeLogger) Warn(args ...any)  { t.each(func(l Logger) { l.Warn(args...) }) }
+func (t *teeLogger) Error(args ...any) { t.each(func(l Logger) { l.Error(args...) }) }
+func (t *teeLogger) Fatal(args ...any) { t.fatal(func(l Logger) { l.Fatal(args...) }) }
+
+func (t *teeLogger) Debugf(format string, args ...any) {
+	t.each(func(l Logger) { l.Debugf(format, args...) })
+}
+
+func (t *teeLogger) Infof(format string, args ...any) {
+	t.each(func(l Logger) { l.Infof(format, args...) })
+}
+
+func (t *teeLogger) Warnf(format string, args ...any) {
+	t.each(func(l Logger) { l.Warnf(format, args...) })
+}
+
+func (t *teeLogger) Errorf(format string, args ...any) {
+	t.each(func(l Logger) { l.Errorf(format, args...) })
+}
+
+func (t *teeLogger) Fatalf(format string, args ...any) {
+	t.fatal(func(l Logger) { l.Fatalf(format, args...) })
+}
+
+func (t *teeLogger) With(fields ...any) Logger {
+	return t.derive(func(l Logger) Logger { return l.With(fields...) })
+}
+
+func (t *teeLogger) Named(name string) Logger {
+	return t.derive(func(l Logger) Logger { return l.Named(name) })
+}
+
+func (t *teeLogger) derive(fn func(Logger) Logger) Logger {
+	derived := &teeLogger{loggers: make([]Logger, len(t.loggers)), exit: t.exit}
+	for i, l := range t.loggers {
+		derived.loggers[i] = fn(l)
+	}
+	return derived
+}
+
+func (t *teeLogger) Debugw(msg string, fields ...any) {
+	t.each(func(l Logger) { l.Debugw(msg, fields...) })
+}
+
+func (t *teeLogger) Infow(msg string, fields ...any) {
+	t.each(func(l Logger) { l.Infow(msg, fields...) })
+}
+
+func (t *teeLogger) Warnw(msg string, fields ...any) {
+	t.each(func(l Logger) { l.Warnw(msg, fields...) })
+}
+
+func (t *teeLogger) Errorw(msg string, fields ...any) {
+	t.each(func(l Logger) { l.Errorw(msg, fields...) })
+}
+
+func (t *teeLogger) Fatalw(msg string, fields ...any) {
+	t.fatal(func(l Logger) { l.Fatalw(msg, fields...) })
+}
+
+func (t *teeLogger) Printf(format string, args ...any) {
+	t.each(func(l Logger) { l.Printf(format, args...) })
+}
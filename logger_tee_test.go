@@ -0,0 +1,114 @@
+package ezutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFanoutLogger(t *testing.T) {
+	var text, jsonOut bytes.Buffer
+	logger := ezutil.NewFanoutLogger("app", []ezutil.Sink{
+		{Writer: &text, MinLevel: ezutil.LevelDebug},
+		{Writer: &jsonOut, MinLevel: ezutil.LevelWarn, JSON: true},
+	})
+
+	logger.Debug("starting")
+	logger.With("order", 42).Named("billing").Warnw("payment slow", "ms", 900)
+
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "[app DEBUG] starting")
+	assert.Contains(t, lines[1], "[app.billing WARN] payment slow order=42 ms=900")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "app.billing", entry["namespace"])
+	assert.Equal(t, "payment slow", entry["msg"])
+	assert.Equal(t, float64(42), entry["order"])
+}
+
+func TestNewFanoutLogger_FatalExitsOnce(t *testing.T) {
+	var a, b bytes.Buffer
+	var codes []int
+	logger := ezutil.NewFanoutLogger("app", []ezutil.Sink{
+		{Writer: &a, MinLevel: ezutil.LevelInfo},
+		{Writer: &b, MinLevel: ezutil.LevelError, JSON: true},
+	}, ezutil.WithExitFunc(func(code int) { codes = append(codes, code) }))
+
+	logger.Fatalf("cannot start: %s", "no config")
+
+	assert.Contains(t, a.String(), "cannot start: no config")
+	assert.Contains(t, b.String(), `"msg":"cannot start: no config"`)
+	assert.Equal(t, []int{1}, codes)
+}
+
+func TestNewTeeLogger_FatalThroughWrappedLoggers(t *testing.T) {
+	var a, b, c bytes.Buffer
+	var codes []int
+	recordExit := func(code int) { codes = append(codes, code) }
+	exit := ezutil.WithExitFunc(recordExit)
+	logger := ezutil.NewTeeLogger(
+		ezutil.NewRedactingLogger(ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&a), exit), ezutil.RedactionConfig{}),
+		ezutil.NewSampledLogger(ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&b), exit), ezutil.SamplingConfig{}),
+		ezutil.NewSlogLogger(slog.New(slog.NewTextHandler(&c, nil)), ezutil.WithSlogExitFunc(recordExit)),
+	)
+
+	logger.Named("db").Fatalw("cannot connect", "password", "hunter2")
+
+	assert.Contains(t, a.String(), "[app.db FATAL] cannot connect password=[REDACTED]")
+	assert.Contains(t, b.String(), "[app.db FATAL] cannot connect password=hunter2")
+	assert.Contains(t, c.String(), `msg="cannot connect"`)
+	assert.Equal(t, []int{1}, codes)
+}
+
+func TestNewTeeLogger(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := ezutil.NewTestLogger()
+	logger := ezutil.NewTeeLogger(
+		ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&buf)),
+		recorder,
+	)
+
+	logger.Debug("hidden from simple logger")
+	logger.Infof("hello %s", "world")
+	logger.Named("db").Errorw("query failed", "table", "users")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "hello world")
+	assert.Contains(t, buf.String(), "[app.db ERROR] query failed table=users")
+
+	entries := recorder.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "hidden from simple logger", entries[0].Message)
+	assert.Equal(t, "db", entries[2].Namespace)
+	assert.Equal(t, []any{"table", "users"}, entries[2].Fields)
+}
+
+func TestNewTeeLogger_NilLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		ezutil.NewTeeLogger(ezutil.NewTestLogger(), nil)
+	})
+}
+
+func TestNewTeeLogger_FlushesAsyncLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewTeeLogger(
+		ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo),
+			ezutil.WithWriter(&buf), ezutil.WithAsync(16, ezutil.OverflowBlock)),
+	)
+
+	logger.Info("queued")
+
+	flusher, ok := logger.(ezutil.Flusher)
+	require.True(t, ok)
+	require.NoError(t, flusher.Flush())
+	assert.Contains(t, buf.String(), "queued")
+}
@@ -1,7 +1,12 @@
 package ezutil_test
 
 import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/itsLeonB/ezutil/v2"
 	"github.com/stretchr/testify/assert"
@@ -17,12 +22,24 @@ func TestNewSimpleLogger_WithColor(t *testing.T) {
 	assert.NotNil(t, logger)
 }
 
+func TestNewSimpleLogger_MinLevel(t *testing.T) {
+	var buf bytes.Buffer
+	minLevel := 2
+	ezutil.NewSimpleLogger("INT", false, minLevel, ezutil.WithWriter(&buf)).Info("hidden")
+	ezutil.NewSimpleLogger("INT", false, minLevel, ezutil.WithWriter(&buf)).Warn("shown")
+	ezutil.NewSimpleLogger("TYPED", false, 0, ezutil.WithLevel(ezutil.LevelError), ezutil.WithWriter(&buf)).Warn("hidden")
+	ezutil.NewSimpleLogger("TYPED", false, 0, ezutil.WithLevel(ezutil.LevelError), ezutil.WithWriter(&buf)).Error("shown")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Equal(t, 2, strings.Count(buf.String(), "shown"))
+}
+
 func TestLogger_Interface(t *testing.T) {
 	logger := ezutil.NewSimpleLogger("TEST", false, 0)
-	
+
 	// Test that it implements the Logger interface
 	var _ ezutil.Logger = logger
-	
+
 	// Test basic methods don't panic
 	logger.Debug("test")
 	logger.Info("test")
@@ -34,3 +51,215 @@ func TestLogger_Interface(t *testing.T) {
 	logger.Errorf("test %s", "format")
 	logger.Printf("test %s", "format")
 }
+
+func TestLogger_With(t *testing.T) {
+	logger := ezutil.NewSimpleLogger("TEST", false, 0)
+
+	child := logger.With("request_id", "abc")
+	assert.NotNil(t, child)
+	assert.NotSame(t, logger, child)
+
+	child.Infow("structured", "user_id", 42)
+	child.With("extra", true).Errorw("nested")
+}
+
+func TestNewSimpleLogger_WithJSONOutput(t *testing.T) {
+	logger := ezutil.NewSimpleLogger("TEST", true, 0, ezutil.WithJSONOutput())
+	assert.NotNil(t, logger)
+
+	logger.Infow("json", "key", "value")
+}
+
+func TestNewSimpleLogger_WithWriters(t *testing.T) {
+	var out, errOut bytes.Buffer
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&out), ezutil.WithErrorWriter(&errOut))
+
+	logger.Info("to out")
+	logger.Error("to err")
+
+	assert.Contains(t, out.String(), "to out")
+	assert.NotContains(t, out.String(), "to err")
+	assert.Contains(t, errOut.String(), "to err")
+}
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	close(w.started)
+	<-w.release
+	return len(p), nil
+}
+
+func TestNewSimpleLogger_WritersLockedSeparately(t *testing.T) {
+	slow := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(slow.release)
+	go ezutil.NewSimpleLogger("SLOW", false, 0, ezutil.WithWriter(slow)).Info("stuck")
+	<-slow.started
+
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		ezutil.NewSimpleLogger("FAST", false, 0, ezutil.WithWriter(&out)).Info("not blocked")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		assert.Contains(t, out.String(), "not blocked")
+	case <-time.After(time.Second):
+		t.Fatal("a slow writer blocked a logger writing elsewhere")
+	}
+}
+
+// loggingWriter reports every write through another logger.
+type loggingWriter struct {
+	logger ezutil.Logger
+}
+
+func (w loggingWriter) Write(p []byte) (int, error) {
+	w.logger.Debugw("wrote log line", "bytes", len(p))
+	return len(p), nil
+}
+
+func TestNewSimpleLogger_WriterThatLogs(t *testing.T) {
+	var audit bytes.Buffer
+	auditLogger := ezutil.NewSimpleLogger("AUDIT", false, 0, ezutil.WithWriter(&audit))
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(loggingWriter{auditLogger}))
+
+	logger.Info("hello")
+
+	assert.Contains(t, audit.String(), "wrote log line bytes=")
+}
+
+func TestNewSimpleLogger_WithExitFunc(t *testing.T) {
+	var buf bytes.Buffer
+	var codes []int
+	logger := ezutil.NewSimpleLogger("TEST", false, 0,
+		ezutil.WithWriter(&buf),
+		ezutil.WithExitFunc(func(code int) { codes = append(codes, code) }),
+	)
+
+	logger.Fatal("fatal")
+	logger.With("k", "v").Fatalf("fatal %s", "formatted")
+	logger.Fatalw("fatal structured")
+
+	assert.Equal(t, []int{1, 1, 1}, codes)
+	assert.Equal(t, 3, strings.Count(buf.String(), "FATAL"))
+}
+
+func TestLogger_Named(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&buf))
+
+	logger.With("request_id", "r-1").Named("billing").Named("stripe").Info("charged")
+	logger.Named("").Info("unchanged")
+
+	assert.Contains(t, buf.String(), "[app.billing.stripe INFO] charged request_id=r-1")
+	assert.Contains(t, buf.String(), "[app INFO] unchanged")
+}
+
+func TestLogger_NamespaceLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("app", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(&buf))
+	billing := logger.Named("billing")
+	stripe := billing.Named("stripe")
+	other := logger.Named("billingx")
+
+	controller, ok := logger.(ezutil.NamespaceLevelController)
+	assert.True(t, ok)
+	controller.SetNamespaceLevel("app.billing", ezutil.LevelDebug)
+	controller.SetNamespaceLevel("app.billing.stripe", ezutil.LevelError)
+
+	billing.Debug("billing debug")
+	stripe.Warn("stripe warn")
+	stripe.Error("stripe error")
+	other.Debug("other debug")
+	logger.Debug("root debug")
+
+	output := buf.String()
+	assert.Contains(t, output, "billing debug")
+	assert.NotContains(t, output, "stripe warn")
+	assert.Contains(t, output, "stripe error")
+	assert.NotContains(t, output, "other debug")
+	assert.NotContains(t, output, "root debug")
+
+	controller.ClearNamespaceLevel("app.billing")
+	buf.Reset()
+	billing.Debug("after clear")
+	assert.Empty(t, buf.String())
+}
+
+func TestNewSimpleLogger_WithCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&buf), ezutil.WithCaller())
+
+	logger.With("k", "v").Named("child").Infow("located")
+	logger.Printf("goose")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Regexp(t, `caller=[^ /]+/logger_test.go:\d+ `, line)
+		assert.Contains(t, line, "func=github.com/itsLeonB/ezutil/v2_test.TestNewSimpleLogger_WithCaller")
+	}
+}
+
+func TestNewSimpleLogger_WithCallerThroughWrappers(t *testing.T) {
+	var buf bytes.Buffer
+	simple := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&buf), ezutil.WithCaller())
+	logger := ezutil.NewTeeLogger(ezutil.NewRedactingLogger(
+		ezutil.NewSampledLogger(simple, ezutil.SamplingConfig{First: 10}), ezutil.RedactionConfig{}))
+
+	logger.Named("child").Infof("wrapped %d", 1)
+
+	assert.Regexp(t, `caller=[^ /]+/logger_test.go:\d+ `, buf.String())
+	assert.Contains(t, buf.String(), "func=github.com/itsLeonB/ezutil/v2_test.TestNewSimpleLogger_WithCallerThroughWrappers")
+}
+
+func TestNewSimpleLogger_WithStacktrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&buf), ezutil.WithStacktrace(ezutil.LevelError))
+
+	logger.Warn("no stack")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	logger.Error("with stack")
+	assert.Contains(t, buf.String(), "\tgithub.com/itsLeonB/ezutil/v2_test.TestNewSimpleLogger_WithStacktrace\n")
+	assert.Contains(t, buf.String(), "logger_test.go:")
+}
+
+func TestSlogHandler_WithCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ezutil.NewSlogHandler(ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&buf), ezutil.WithCaller())))
+
+	logger.Info("through slog")
+
+	assert.Regexp(t, `caller=[^ /]+/logger_test.go:\d+ `, buf.String())
+}
+
+func TestNewSimpleLogger_WithAsync(t *testing.T) {
+	var buf bytes.Buffer
+	logger := ezutil.NewSimpleLogger("TEST", false, 0, ezutil.WithWriter(&buf), ezutil.WithAsync(4, ezutil.OverflowBlock))
+
+	for i := range 10 {
+		logger.Infof("entry %d", i)
+	}
+
+	flusher, ok := logger.(ezutil.Flusher)
+	assert.True(t, ok)
+	assert.NoError(t, flusher.Flush())
+	assert.Equal(t, 10, strings.Count(buf.String(), "entry"))
+
+	counter, ok := logger.(ezutil.DropCounter)
+	assert.True(t, ok)
+	assert.Zero(t, counter.Dropped())
+
+	closer, ok := logger.(io.Closer)
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+}
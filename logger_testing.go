@@ -0,0 +1,182 @@
+package ezutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+)
+
+// TestingT is the subset of testing.TB used by the TestLogger assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// LogEntry is an entry recorded by TestLogger.
+type LogEntry struct {
+	Level     Level
+	Namespace string
+	Message   string
+	// Fields holds the key-value pairs from With followed by those passed to the w methods.
+	Fields []any
+	// Print is set for entries written through Print, Println or the goose.Logger Printf method.
+	Print bool
+}
+
+// Field returns the last value recorded for key and whether it was present.
+func (e LogEntry) Field(key string) (any, bool) {
+	for i := len(e.Fields) - 2; i >= 0; i -= 2 {
+		if k, ok := e.Fields[i].(string); ok && k == key {
+			return e.Fields[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// TestLogger is a Logger that records entries in memory instead of writing them, so tests
+// can assert on logging behavior. Fatal methods are recorded at LevelFatal and do not exit.
+// Loggers derived with With and Named record into the same list. It is safe for concurrent use.
+type TestLogger struct {
+	state     *testLogState
+	namespace string
+	fields    []any
+}
+
+type testLogState struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// NewTestLogger returns an empty TestLogger.
+func NewTestLogger() *TestLogger {
+	return &TestLogger{state: &testLogState{}}
+}
+
+// Entries returns a copy of every entry recorded so far, in order.
+func (l *TestLogger) Entries() []LogEntry {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	return slices.Clone(l.state.entries)
+}
+
+// EntriesAt returns the recorded entries with the given level.
+func (l *TestLogger) EntriesAt(level Level) []LogEntry {
+	var result []LogEntry
+	for _, e := range l.Entries() {
+		if e.Level == level {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Reset discards the recorded entries.
+func (l *TestLogger) Reset() {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	l.state.entries = nil
+}
+
+// AssertLogged reports a test error unless an entry at level contains substring in its message.
+func (l *TestLogger) AssertLogged(t TestingT, level Level, substring string) bool {
+	t.Helper()
+	if l.find(level, substring) {
+		return true
+	}
+	t.Errorf("expected a %s entry containing %q, got:\n%s", level, substring, l.dump())
+	return false
+}
+
+// AssertNotLogged reports a test error if an entry at level contains substring in its message.
+func (l *TestLogger) AssertNotLogged(t TestingT, level Level, substring string) bool {
+	t.Helper()
+	if !l.find(level, substring) {
+		return true
+	}
+	t.Errorf("unexpected %s entry containing %q, got:\n%s", level, substring, l.dump())
+	return false
+}
+
+func (l *TestLogger) find(level Level, substring string) bool {
+	for _, e := range l.Entries() {
+		if e.Level == level && strings.Contains(e.Message, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *TestLogger) dump() string {
+	entries := l.Entries()
+	if len(entries) == 0 {
+		return "\t(no entries)"
+	}
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = fmt.Sprintf("\t[%s %s] %s %v", e.Namespace, e.Level, e.Message, e.Fields)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l *TestLogger) record(level Level, msg string, fields []any, fromPrint bool) {
+	entry := LogEntry{
+		Level:     level,
+		Namespace: l.namespace,
+		Message:   msg,
+		Fields:    append(slices.Clip(l.fields), fields...),
+		Print:     fromPrint,
+	}
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	l.state.entries = append(l.state.entries, entry)
+}
+
+func (l *TestLogger) Debug(args ...any) { l.record(LevelDebug, sprintln(args...), nil, false) }
+func (l *TestLogger) Info(args ...any)  { l.record(LevelInfo, sprintln(args...), nil, false) }
+func (l *TestLogger) Warn(args ...any)  { l.record(LevelWarn, sprintln(args...), nil, false) }
+func (l *TestLogger) Error(args ...any) { l.record(LevelError, sprintln(args...), nil, false) }
+func (l *TestLogger) Fatal(args ...any) { l.record(LevelFatal, sprintln(args...), nil, false) }
+
+func (l *TestLogger) Debugf(format string, args ...any) {
+	l.record(LevelDebug, fmt.Sprintf(format, args...), nil, false)
+}
+
+func (l *TestLogger) Infof(format string, args ...any) {
+	l.record(LevelInfo, fmt.Sprintf(format, args...), nil, false)
+}
+
+func (l *TestLogger) Warnf(format string, args ...any) {
+	l.record(LevelWarn, fmt.Sprintf(format, args...), nil, false)
+}
+
+func (l *TestLogger) Errorf(format string, args ...any) {
+	l.record(LevelError, fmt.Sprintf(format, args...), nil, false)
+}
+
+func (l *TestLogger) Fatalf(format string, args ...any) {
+	l.record(LevelFatal, fmt.Sprintf(format, args...), nil, false)
+}
+
+func (l *TestLogger) With(fields ...any) Logger {
+	return &TestLogger{state: l.state, namespace: l.namespace, fields: append(slices.Clip(l.fields), fields...)}
+}
+
+func (l *TestLogger) Named(name string) Logger {
+	return &TestLogger{state: l.state, namespace: internal.JoinNamespace(l.namespace, name), fields: l.fields}
+}
+
+func (l *TestLogger) Debugw(msg string, fields ...any) { l.record(LevelDebug, msg, fields, false) }
+func (l *TestLogger) Infow(msg string, fields ...any)  { l.record(LevelInfo, msg, fields, false) }
+func (l *TestLogger) Warnw(msg string, fields ...any)  { l.record(LevelWarn, msg, fields, false) }
+func (l *TestLogger) Errorw(msg string, fields ...any) { l.record(LevelError, msg, fields, false) }
+func (l *TestLogger) Fatalw(msg string, fields ...any) { l.record(LevelFatal, msg, fields, false) }
+
+func (l *TestLogger) Print(args ...any)   { l.record(LevelInfo, fmt.Sprint(args...), nil, true) }
+func (l *TestLogger) Println(args ...any) { l.record(LevelInfo, sprintln(args...), nil, true) }
+
+func (l *TestLogger) Printf(format string, args ...any) {
+	l.record(LevelInfo, fmt.Sprintf(format, args...), nil, true)
+}
@@ -0,0 +1,136 @@
+package ezutil_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeT records assertion failures so the TestLogger helpers can be tested.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTestLogger_RecordsEntries(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+
+	logger.Debug("debug", 1)
+	logger.Infof("info %s", "formatted")
+	logger.Warnw("warn", "key", "value")
+	logger.Error("error")
+	logger.Fatalf("fatal %d", 2)
+
+	entries := logger.Entries()
+	assert.Len(t, entries, 5)
+	assert.Equal(t, ezutil.LogEntry{Level: ezutil.LevelDebug, Message: "debug 1"}, entries[0])
+	assert.Equal(t, "info formatted", entries[1].Message)
+	assert.Equal(t, []any{"key", "value"}, entries[2].Fields)
+	assert.Equal(t, ezutil.LevelError, entries[3].Level)
+	assert.Equal(t, ezutil.LevelFatal, entries[4].Level)
+	assert.Equal(t, "fatal 2", entries[4].Message)
+}
+
+func TestTestLogger_WithAndNamed(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+
+	logger.Named("app").With("request_id", "r-1").Named("billing").Infow("charged", "amount", 10)
+
+	entries := logger.Entries()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "app.billing", entries[0].Namespace)
+	assert.Equal(t, []any{"request_id", "r-1", "amount", 10}, entries[0].Fields)
+
+	value, ok := entries[0].Field("amount")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	_, ok = entries[0].Field("missing")
+	assert.False(t, ok)
+}
+
+func TestTestLogger_GoosePrint(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	var gooseLogger goose.Logger = logger
+
+	gooseLogger.Printf("OK   %s", "00001_init.sql")
+	logger.Print("print")
+	logger.Println("println", "args")
+
+	entries := logger.Entries()
+	assert.Len(t, entries, 3)
+	for _, e := range entries {
+		assert.True(t, e.Print)
+		assert.Equal(t, ezutil.LevelInfo, e.Level)
+	}
+	assert.Equal(t, "OK   00001_init.sql", entries[0].Message)
+	assert.Equal(t, "println args", entries[2].Message)
+}
+
+func TestTestLogger_Assertions(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	logger.Errorf("payment failed: %v", errors.New("card declined"))
+
+	assert.True(t, logger.AssertLogged(t, ezutil.LevelError, "card declined"))
+	assert.True(t, logger.AssertNotLogged(t, ezutil.LevelWarn, "card declined"))
+
+	ft := &fakeT{}
+	assert.False(t, logger.AssertLogged(ft, ezutil.LevelInfo, "card declined"))
+	assert.False(t, logger.AssertNotLogged(ft, ezutil.LevelError, "payment"))
+	assert.Len(t, ft.errors, 2)
+	assert.Contains(t, ft.errors[0], `expected a INFO entry containing "card declined"`)
+	assert.Contains(t, ft.errors[0], "payment failed: card declined")
+	assert.Contains(t, ft.errors[1], `unexpected ERROR entry containing "payment"`)
+
+	empty := &fakeT{}
+	ezutil.NewTestLogger().AssertLogged(empty, ezutil.LevelInfo, "x")
+	assert.Contains(t, empty.errors[0], "(no entries)")
+}
+
+func TestTestLogger_EntriesAtAndReset(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	logger.Info("one")
+	logger.Warn("two")
+	logger.Info("three")
+
+	infos := logger.EntriesAt(ezutil.LevelInfo)
+	assert.Len(t, infos, 2)
+	assert.Equal(t, "three", infos[1].Message)
+
+	logger.Reset()
+	assert.Empty(t, logger.Entries())
+}
+
+func TestTestLogger_Concurrent(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.With("worker", i).Info("done")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, logger.EntriesAt(ezutil.LevelInfo), 10)
+}
+
+func TestTestLogger_WithJob(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+
+	ezutil.NewJob(logger, func() error { return errors.New("boom") }).Run()
+
+	logger.AssertLogged(t, ezutil.LevelInfo, "running job...")
+	logger.AssertLogged(t, ezutil.LevelFatal, "error running job: boom")
+}
@@ -0,0 +1,312 @@
+package ezutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Labels are the label names and values identifying one series of a metric.
+type Labels map[string]string
+
+// Counter is a value that only goes up, such as the number of runs.
+type Counter interface {
+	Inc()
+	// Add increases the counter by delta, which must not be negative.
+	Add(delta float64)
+}
+
+// Gauge is a value that goes up and down, such as the number of jobs in progress.
+type Gauge interface {
+	Set(value float64)
+	Add(delta float64)
+}
+
+// Histogram counts observations, such as durations in seconds, into buckets.
+type Histogram interface {
+	Observe(value float64)
+}
+
+// Metrics creates or looks up metrics. Calls with the same name and labels return the same
+// series, so callers can look metrics up where they are used instead of holding on to them.
+type Metrics interface {
+	Counter(name, help string, labels Labels) Counter
+	Gauge(name, help string, labels Labels) Gauge
+	// Histogram uses buckets, the sorted upper bounds of the buckets, the first time name is
+	// used; nil means DefaultLatencyBuckets.
+	Histogram(name, help string, buckets []float64, labels Labels) Histogram
+}
+
+// DefaultLatencyBuckets are histogram buckets in seconds suited to request and job latencies.
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// ObserveLatency runs f like MeasureLatency and records its duration in seconds in h.
+func ObserveLatency(h Histogram, f func()) time.Duration {
+	latency := MeasureLatency(f)
+	h.Observe(latency.Seconds())
+	return latency
+}
+
+// MetricsRegistry is an in-memory Metrics implementation. It is an http.Handler serving its
+// metrics in the Prometheus text exposition format, so it can be mounted at /metrics.
+type MetricsRegistry struct {
+	mu       sync.RWMutex
+	families map[string]*metricFamily
+}
+
+type metricType string
+
+const (
+	counterType   metricType = "counter"
+	gaugeType     metricType = "gauge"
+	histogramType metricType = "histogram"
+)
+
+type metricFamily struct {
+	help    string
+	typ     metricType
+	buckets []float64
+	series  map[string]metricSeries
+}
+
+type metricSeries interface {
+	// write appends the exposition lines of the series, whose labels are rendered as by labelKey.
+	write(w *bufio.Writer, name, labels string)
+}
+
+type labelPair struct {
+	name, value string
+}
+
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{families: make(map[string]*metricFamily)}
+}
+
+func (r *MetricsRegistry) Counter(name, help string, labels Labels) Counter {
+	return r.series(name, help, counterType, nil, labels, func() metricSeries { return &floatValue{} }).(Counter)
+}
+
+func (r *MetricsRegistry) Gauge(name, help string, labels Labels) Gauge {
+	return r.series(name, help, gaugeType, nil, labels, func() metricSeries { return &floatValue{} }).(Gauge)
+}
+
+func (r *MetricsRegistry) Histogram(name, help string, buckets []float64, labels Labels) Histogram {
+	if buckets == nil {
+		buckets = DefaultLatencyBuckets
+	}
+	return r.series(name, help, histogramType, buckets, labels, nil).(Histogram)
+}
+
+// series returns the series of name with labels, creating the family and series as needed.
+// It panics if name is already used by a metric of another type.
+func (r *MetricsRegistry) series(name, help string, typ metricType, buckets []float64, labels Labels, create func() metricSeries) metricSeries {
+	key := labelKey(labels)
+
+	r.mu.RLock()
+	family, ok := r.families[name]
+	if ok && family.typ == typ {
+		if s, ok := family.series[key]; ok {
+			r.mu.RUnlock()
+			return s
+		}
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	family, ok = r.families[name]
+	if !ok {
+		family = &metricFamily{help: help, typ: typ, buckets: slices.Clone(buckets), series: make(map[string]metricSeries)}
+		r.families[name] = family
+	}
+	if family.typ != typ {
+		panic(fmt.Sprintf("metric %q is already registered as a %s", name, family.typ))
+	}
+	s, ok := family.series[key]
+	if !ok {
+		if typ == histogramType {
+			s = newHistogram(family.buckets)
+		} else {
+			s = create()
+		}
+		family.series[key] = s
+	}
+	return s
+}
+
+// labelKey renders labels sorted by name, which doubles as the series key and its exposition text.
+func labelKey(labels Labels) string {
+	pairs := sortedLabels(labels)
+	var b strings.Builder
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(p.name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(p.value))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+func sortedLabels(labels Labels) []labelPair {
+	pairs := make([]labelPair, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, labelPair{name, value})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].name < pairs[j].name })
+	return pairs
+}
+
+var (
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+// WriteText writes every metric in the Prometheus text exposition format, sorted by name and labels.
+func (r *MetricsRegistry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		family := r.families[name]
+		if family.help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", name, helpEscaper.Replace(family.help))
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, family.typ)
+
+		keys := make([]string, 0, len(family.series))
+		for key := range family.series {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			family.series[key].write(bw, name, key)
+		}
+	}
+	return bw.Flush()
+}
+
+// formatLabels wraps rendered labels, plus extra if set, in braces as in {a="b",le="1"}.
+func formatLabels(labels, extra string) string {
+	switch {
+	case labels == "" && extra == "":
+		return ""
+	case labels == "":
+		return "{" + extra + "}"
+	case extra == "":
+		return "{" + labels + "}"
+	default:
+		return "{" + labels + "," + extra + "}"
+	}
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func (r *MetricsRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if req.Method == http.MethodHead {
+		return
+	}
+	_ = r.WriteText(w)
+}
+
+// floatValue backs counters and gauges with a float64 stored as bits for atomic updates.
+type floatValue struct {
+	bits atomic.Uint64
+}
+
+func (v *floatValue) Inc() { v.Add(1) }
+
+func (v *floatValue) Add(delta float64) {
+	for {
+		old := v.bits.Load()
+		updated := math.Float64bits(math.Float64frombits(old) + delta)
+		if v.bits.CompareAndSwap(old, updated) {
+			return
+		}
+	}
+}
+
+func (v *floatValue) Set(value float64) {
+	v.bits.Store(math.Float64bits(value))
+}
+
+func (v *floatValue) write(w *bufio.Writer, name, labels string) {
+	fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(labels, ""), formatFloat(math.Float64frombits(v.bits.Load())))
+}
+
+type histogram struct {
+	mu     sync.Mutex
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i := sort.SearchFloat64s(h.bounds, value); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+func (h *histogram) write(w *bufio.Writer, name, labels string) {
+	h.mu.Lock()
+	counts := slices.Clone(h.counts)
+	count, sum := h.count, h.sum
+	h.mu.Unlock()
+
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += counts[i]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(labels, `le="`+formatFloat(bound)+`"`), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(labels, `le="+Inf"`), count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, formatLabels(labels, ""), formatFloat(sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, formatLabels(labels, ""), count)
+}
@@ -0,0 +1,94 @@
+package ezutil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRegistry_WriteText(t *testing.T) {
+	registry := ezutil.NewMetricsRegistry()
+
+	registry.Counter("requests_total", "Requests served.", ezutil.Labels{"method": "GET", "code": "200"}).Inc()
+	registry.Counter("requests_total", "Requests served.", ezutil.Labels{"code": "200", "method": "GET"}).Add(2)
+	registry.Counter("requests_total", "Requests served.", ezutil.Labels{"method": "POST", "code": "500"}).Inc()
+	gauge := registry.Gauge("queue_size", "", nil)
+	gauge.Set(5)
+	gauge.Add(-2)
+	histogram := registry.Histogram("latency_seconds", "Request latency.\nIn seconds.", []float64{0.1, 1}, ezutil.Labels{"path": `/a"b`})
+	histogram.Observe(0.05)
+	histogram.Observe(0.1)
+	histogram.Observe(3)
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+
+	expected := `# HELP latency_seconds Request latency.\nIn seconds.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{path="/a\"b",le="0.1"} 2
+latency_seconds_bucket{path="/a\"b",le="1"} 2
+latency_seconds_bucket{path="/a\"b",le="+Inf"} 3
+latency_seconds_sum{path="/a\"b"} 3.15
+latency_seconds_count{path="/a\"b"} 3
+# TYPE queue_size gauge
+queue_size 3
+# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{code="200",method="GET"} 3
+requests_total{code="500",method="POST"} 1
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestMetricsRegistry_DefaultBuckets(t *testing.T) {
+	registry := ezutil.NewMetricsRegistry()
+	registry.Histogram("duration_seconds", "", nil, nil).Observe(0.2)
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	assert.Contains(t, b.String(), `duration_seconds_bucket{le="0.25"} 1`)
+	assert.Contains(t, b.String(), `duration_seconds_bucket{le="0.1"} 0`)
+	assert.Equal(t, len(ezutil.DefaultLatencyBuckets)+1, strings.Count(b.String(), "duration_seconds_bucket"))
+}
+
+func TestMetricsRegistry_TypeConflict(t *testing.T) {
+	registry := ezutil.NewMetricsRegistry()
+	registry.Counter("events", "", nil)
+	assert.PanicsWithValue(t, `metric "events" is already registered as a counter`, func() {
+		registry.Gauge("events", "", nil)
+	})
+}
+
+func TestMetricsRegistry_ServeHTTP(t *testing.T) {
+	registry := ezutil.NewMetricsRegistry()
+	registry.Counter("hits_total", "Hits.", nil).Inc()
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "# HELP hits_total Hits.\n# TYPE hits_total counter\nhits_total 1\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestObserveLatency(t *testing.T) {
+	registry := ezutil.NewMetricsRegistry()
+	histogram := registry.Histogram("op_seconds", "", []float64{10}, nil)
+
+	latency := ezutil.ObserveLatency(histogram, func() { time.Sleep(5 * time.Millisecond) })
+	assert.GreaterOrEqual(t, latency, 5*time.Millisecond)
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	assert.Contains(t, b.String(), "op_seconds_count 1\n")
+	assert.Contains(t, b.String(), `op_seconds_bucket{le="10"} 1`)
+}
@@ -0,0 +1,49 @@
+package ezutil
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error a Job reports when one of its functions panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error, so errors.Is and errors.As see through it.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callRecover calls fn, converting a panic into a *PanicError.
+func callRecover(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+// recovering wraps a job function so that a panic is logged with its stack and returned as a *PanicError.
+func (j *Job) recovering(stage string, fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				panicErr := &PanicError{Value: v, Stack: debug.Stack()}
+				j.logger.Errorw("recovered panic in job", "stage", stage, "panic", fmt.Sprint(v), "stack", string(panicErr.Stack))
+				err = panicErr
+			}
+		}()
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,64 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_RecoversRunPanic(t *testing.T) {
+	cleanedUp := false
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error { panic("boom") }).
+		WithCleanupFunc(func() error {
+			cleanedUp = true
+			return nil
+		}).
+		Execute(context.Background())
+
+	assert.True(t, cleanedUp)
+	var panicErr *ezutil.PanicError
+	require.ErrorAs(t, result.RunErr, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "panic_test.go")
+	assert.EqualError(t, result.Err(), "error running job: panic: boom")
+}
+
+func TestJob_RecoversSetupAndCleanupPanics(t *testing.T) {
+	setupErr := errors.New("bad config")
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithSetupFunc(func() error { panic(setupErr) }).
+		Execute(context.Background())
+	assert.ErrorIs(t, result.SetupErr, setupErr)
+
+	result = ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithCleanupFunc(func() error { panic("cleanup") }).
+		Execute(context.Background())
+	assert.NoError(t, result.RunErr)
+	assert.EqualError(t, result.CleanupErr, "panic: cleanup")
+}
+
+func TestJob_RetriesRunPanic(t *testing.T) {
+	calls := 0
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		calls++
+		if calls == 1 {
+			panic("flaky")
+		}
+		return nil
+	}).
+		WithRetry(ezutil.RetryPolicy{MaxAttempts: 2, InitialBackoff: 1}).
+		Execute(context.Background())
+
+	assert.NoError(t, result.Err())
+	assert.Equal(t, 2, result.Attempts)
+}
+
+func TestJob_RunEReturnsPanic(t *testing.T) {
+	err := ezutil.NewJob(ezutil.NewTestLogger(), func() error { panic("boom") }).RunE()
+	var panicErr *ezutil.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
@@ -0,0 +1,208 @@
+package ezutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+// Pipeline runs named steps as a dependency graph. A step starts once every step it depends
+// on has succeeded, so independent steps run concurrently. When a step fails, the steps that
+// depend on it, directly or not, are skipped while unrelated branches carry on. A panic in a
+// step is recovered and fails the step with a *PanicError.
+//
+// A Pipeline can be the run function of a Job through NewJobContext(logger, pipeline.Run).
+type Pipeline struct {
+	logger Logger
+	steps  []*pipelineStep
+}
+
+type pipelineStep struct {
+	name      string
+	fn        func(ctx context.Context) error
+	dependsOn []string
+}
+
+// StepResult describes the outcome of a pipeline step.
+type StepResult struct {
+	Name string
+	// Err is the error returned by the step, or the context error if the pipeline was
+	// cancelled before the step started.
+	Err error
+	// Skipped is set when the step did not run because a dependency failed or was skipped.
+	Skipped bool
+	Latency time.Duration
+}
+
+// PipelineResult describes a finished pipeline execution.
+type PipelineResult struct {
+	// Steps holds one result per step, in the order the steps were added.
+	Steps []StepResult
+}
+
+// Err joins the errors of the failed steps, or returns nil when every step succeeded.
+func (r PipelineResult) Err() error {
+	var errs []error
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			errs = append(errs, fmt.Errorf("step %q: %w", step.Name, step.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Step returns the result of the named step and whether it exists.
+func (r PipelineResult) Step(name string) (StepResult, bool) {
+	for _, step := range r.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return StepResult{}, false
+}
+
+func NewPipeline(logger Logger) *Pipeline {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	return &Pipeline{logger: logger}
+}
+
+// Step adds a step named name that runs after the steps in dependsOn have succeeded.
+// Names must be unique; duplicates, unknown dependencies and cycles are reported by Execute.
+func (p *Pipeline) Step(name string, fn func(ctx context.Context) error, dependsOn ...string) *Pipeline {
+	if fn == nil {
+		panic("step function cannot be nil")
+	}
+	p.steps = append(p.steps, &pipelineStep{name: name, fn: fn, dependsOn: dependsOn})
+	return p
+}
+
+// Job adds job as a step, running it with Job.Execute so that a failure fails the step
+// instead of exiting the process.
+func (p *Pipeline) Job(name string, job *Job, dependsOn ...string) *Pipeline {
+	if job == nil {
+		panic("job cannot be nil")
+	}
+	return p.Step(name, func(ctx context.Context) error { return job.Execute(ctx).Err() }, dependsOn...)
+}
+
+// Run executes the pipeline and returns the joined errors of the failed steps.
+func (p *Pipeline) Run(ctx context.Context) error {
+	result, err := p.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+// Execute validates the graph and runs every step, logging each step's latency.
+// It returns an error without running anything if the graph is invalid.
+func (p *Pipeline) Execute(ctx context.Context) (PipelineResult, error) {
+	if err := p.validate(); err != nil {
+		return PipelineResult{}, err
+	}
+
+	results := make([]StepResult, len(p.steps))
+	done := make(map[string]chan struct{}, len(p.steps))
+	index := make(map[string]int, len(p.steps))
+	for i, step := range p.steps {
+		done[step.name] = make(chan struct{})
+		index[step.name] = i
+		results[i].Name = step.name
+	}
+
+	var wg sync.WaitGroup
+	for i, step := range p.steps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[step.name])
+
+			for _, dep := range step.dependsOn {
+				<-done[dep]
+				if depResult := results[index[dep]]; depResult.Err != nil || depResult.Skipped {
+					results[i].Skipped = true
+					p.logger.Warnw("skipping pipeline step, dependency did not succeed", "step", step.name, "dependency", dep)
+					return
+				}
+			}
+			results[i] = p.runStep(ctx, step)
+		}()
+	}
+	wg.Wait()
+
+	return PipelineResult{Steps: results}, nil
+}
+
+func (p *Pipeline) runStep(ctx context.Context, step *pipelineStep) StepResult {
+	result := StepResult{Name: step.name}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		p.logger.Warnw("pipeline cancelled before step started", "step", step.name, "error", err)
+		return result
+	}
+
+	p.logger.Infow("running pipeline step", "step", step.name)
+	result.Latency = MeasureLatency(func() {
+		result.Err = callRecover(func() error { return step.fn(ctx) })
+	})
+	if result.Err != nil {
+		p.logger.Errorw("pipeline step failed", "step", step.name, "latency_ms", result.Latency.Milliseconds(), "error", result.Err)
+		return result
+	}
+	p.logger.Infow("finished pipeline step", "step", step.name, "latency_ms", result.Latency.Milliseconds())
+	return result
+}
+
+// validate rejects duplicate names, unknown dependencies and cycles.
+func (p *Pipeline) validate() error {
+	steps := make(map[string]*pipelineStep, len(p.steps))
+	for _, step := range p.steps {
+		if _, ok := steps[step.name]; ok {
+			return ungerr.Unknownf("duplicate pipeline step %q", step.name)
+		}
+		steps[step.name] = step
+	}
+	for _, step := range p.steps {
+		for _, dep := range step.dependsOn {
+			if _, ok := steps[dep]; !ok {
+				return ungerr.Unknownf("pipeline step %q depends on unknown step %q", step.name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(p.steps))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return ungerr.Unknownf("pipeline has a dependency cycle through step %q", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range steps[name].dependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, step := range p.steps {
+		if err := visit(step.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stepRecorder records the order in which pipeline steps finish.
+type stepRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *stepRecorder) step(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.order = append(r.order, name)
+		return err
+	}
+}
+
+func (r *stepRecorder) finished() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.order...)
+}
+
+func TestNewPipeline_NilLogger(t *testing.T) {
+	assert.Panics(t, func() { ezutil.NewPipeline(nil) })
+}
+
+func TestPipeline_RunsInDependencyOrder(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	rec := &stepRecorder{}
+	p := ezutil.NewPipeline(logger).
+		Step("load", rec.step("load", nil), "transform-users", "transform-orders").
+		Step("transform-users", rec.step("transform-users", nil), "extract").
+		Step("transform-orders", rec.step("transform-orders", nil), "extract").
+		Step("extract", rec.step("extract", nil))
+
+	result, err := p.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.NoError(t, result.Err())
+	order := rec.finished()
+	require.Len(t, order, 4)
+	assert.Equal(t, "extract", order[0])
+	assert.ElementsMatch(t, []string{"transform-users", "transform-orders"}, order[1:3])
+	assert.Equal(t, "load", order[3])
+
+	assert.Len(t, logger.EntriesAt(ezutil.LevelInfo), 8)
+	entry := logger.EntriesAt(ezutil.LevelInfo)[1]
+	assert.Equal(t, "finished pipeline step", entry.Message)
+	_, ok := entry.Field("latency_ms")
+	assert.True(t, ok)
+}
+
+func TestPipeline_RunsIndependentStepsConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	meet := func(context.Context) error {
+		wg.Done()
+		wg.Wait()
+		return nil
+	}
+	p := ezutil.NewPipeline(ezutil.NewTestLogger()).Step("a", meet).Step("b", meet)
+
+	done := make(chan error, 1)
+	go func() { done <- p.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("independent steps did not run concurrently")
+	}
+}
+
+func TestPipeline_FailureSkipsDependents(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	rec := &stepRecorder{}
+	errExtract := errors.New("source unavailable")
+	p := ezutil.NewPipeline(logger).
+		Step("extract", rec.step("extract", errExtract)).
+		Step("transform", rec.step("transform", nil), "extract").
+		Step("load", rec.step("load", nil), "transform").
+		Step("audit", rec.step("audit", nil))
+
+	result, err := p.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"extract", "audit"}, rec.finished())
+
+	extract, _ := result.Step("extract")
+	assert.Equal(t, errExtract, extract.Err)
+	transform, _ := result.Step("transform")
+	assert.True(t, transform.Skipped)
+	load, _ := result.Step("load")
+	assert.True(t, load.Skipped)
+	audit, _ := result.Step("audit")
+	assert.NoError(t, audit.Err)
+	assert.False(t, audit.Skipped)
+
+	assert.ErrorIs(t, result.Err(), errExtract)
+	assert.EqualError(t, result.Err(), `step "extract": source unavailable`)
+	logger.AssertLogged(t, ezutil.LevelError, "pipeline step failed")
+	logger.AssertLogged(t, ezutil.LevelWarn, "skipping pipeline step")
+}
+
+func TestPipeline_PanicFailsStep(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	rec := &stepRecorder{}
+	p := ezutil.NewPipeline(logger).
+		Step("extract", func(context.Context) error { panic("boom") }).
+		Step("load", rec.step("load", nil), "extract").
+		Step("audit", rec.step("audit", nil))
+
+	var err error
+	assert.NotPanics(t, func() {
+		err = ezutil.NewJobContext(ezutil.NewTestLogger(), p.Run).RunE()
+	})
+
+	var panicErr *ezutil.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, []string{"audit"}, rec.finished())
+	logger.AssertLogged(t, ezutil.LevelWarn, "skipping pipeline step")
+}
+
+func TestPipeline_Job(t *testing.T) {
+	rec := &stepRecorder{}
+	jobLogger := ezutil.NewTestLogger()
+	p := ezutil.NewPipeline(ezutil.NewTestLogger()).
+		Job("migrate", ezutil.NewJob(jobLogger, func() error { return errors.New("locked") })).
+		Step("serve", rec.step("serve", nil), "migrate")
+
+	err := p.Run(context.Background())
+
+	assert.EqualError(t, err, `step "migrate": error running job: locked`)
+	assert.Empty(t, rec.finished())
+	assert.Empty(t, jobLogger.EntriesAt(ezutil.LevelFatal))
+}
+
+func TestPipeline_AsJobRunFunc(t *testing.T) {
+	rec := &stepRecorder{}
+	p := ezutil.NewPipeline(ezutil.NewTestLogger()).
+		Step("a", rec.step("a", nil)).
+		Step("b", rec.step("b", nil), "a")
+
+	err := ezutil.NewJobContext(ezutil.NewTestLogger(), p.Run).RunE()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, rec.finished())
+}
+
+func TestPipeline_CancelledContext(t *testing.T) {
+	rec := &stepRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := ezutil.NewPipeline(ezutil.NewTestLogger()).
+		Step("a", rec.step("a", nil)).
+		Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Empty(t, rec.finished())
+	assert.ErrorIs(t, result.Err(), context.Canceled)
+}
+
+func TestPipeline_InvalidGraph(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	tests := []struct {
+		name     string
+		pipeline *ezutil.Pipeline
+		message  string
+	}{
+		{
+			"duplicate",
+			ezutil.NewPipeline(ezutil.NewTestLogger()).Step("a", noop).Step("a", noop),
+			`duplicate pipeline step "a"`,
+		},
+		{
+			"unknown dependency",
+			ezutil.NewPipeline(ezutil.NewTestLogger()).Step("a", noop, "missing"),
+			`pipeline step "a" depends on unknown step "missing"`,
+		},
+		{
+			"cycle",
+			ezutil.NewPipeline(ezutil.NewTestLogger()).Step("a", noop, "c").Step("b", noop, "a").Step("c", noop, "b"),
+			"dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.pipeline.Execute(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package ezutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PoolConfig configures RunPool.
+type PoolConfig struct {
+	// Workers is the number of items processed at once. Defaults to GOMAXPROCS.
+	Workers int
+	// ProgressEvery logs progress each time this many items have been processed. Zero disables progress logs.
+	ProgressEvery int
+	// StopOnError stops handing out items after the first failure instead of processing the rest,
+	// and cancels the context passed to items in progress.
+	StopOnError bool
+}
+
+// ItemError is the failure of one item processed by RunPool.
+type ItemError struct {
+	// Index is the position of the item in the slice given to RunPool.
+	Index int
+	Err   error
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// PoolResult summarizes a RunPool call.
+type PoolResult struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	// Skipped counts the items never processed because the context was cancelled or StopOnError stopped the pool.
+	Skipped int
+	// Errors holds the failed items ordered by index.
+	Errors []ItemError
+	// CtxErr is the context error if the pool was cancelled before processing every item.
+	CtxErr  error
+	Latency time.Duration
+}
+
+// Err reports the cancellation and the number of failed items with the first failure,
+// or nil when every item was processed successfully.
+func (r PoolResult) Err() error {
+	var errs []error
+	if r.CtxErr != nil {
+		errs = append(errs, fmt.Errorf("pool cancelled with %d items skipped: %w", r.Skipped, r.CtxErr))
+	}
+	if len(r.Errors) > 0 {
+		errs = append(errs, fmt.Errorf("%d of %d items failed, first error: %w", r.Failed, r.Total, r.Errors[0]))
+	}
+	return errors.Join(errs...)
+}
+
+// RunPool calls fn for every item with at most cfg.Workers calls running at once. Failures,
+// including panics reported as *PanicError, are collected per item rather than stopping the
+// pool. Cancelling ctx stops handing out items; items in progress observe ctx themselves.
+// Progress and a final summary are logged to logger.
+func RunPool[T any](ctx context.Context, logger Logger, items []T, fn func(ctx context.Context, item T) error, cfg PoolConfig) PoolResult {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	if fn == nil {
+		panic("fn cannot be nil")
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		result    = PoolResult{Total: len(items)}
+		processed int
+	)
+	finish := func(index int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed++
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, ItemError{Index: index, Err: err})
+			logger.Warnw("pool item failed", "index", index, "error", err)
+			if cfg.StopOnError {
+				cancel()
+			}
+		} else {
+			result.Succeeded++
+		}
+		if cfg.ProgressEvery > 0 && processed%cfg.ProgressEvery == 0 {
+			logger.Infow("pool progress", "processed", processed, "total", len(items), "failed", result.Failed)
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	result.Latency = MeasureLatency(func() {
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					finish(i, callRecover(func() error { return fn(poolCtx, items[i]) }))
+				}
+			}()
+		}
+
+	feed:
+		for i := range items {
+			if poolCtx.Err() != nil {
+				break
+			}
+			select {
+			case <-poolCtx.Done():
+				break feed
+			case indexes <- i:
+			}
+		}
+		close(indexes)
+		wg.Wait()
+	})
+
+	result.Skipped = result.Total - result.Succeeded - result.Failed
+	if result.Skipped > 0 {
+		result.CtxErr = ctx.Err()
+	}
+	slices.SortFunc(result.Errors, func(a, b ItemError) int { return a.Index - b.Index })
+
+	logger.Infow("pool finished",
+		"total", result.Total,
+		"succeeded", result.Succeeded,
+		"failed", result.Failed,
+		"skipped", result.Skipped,
+		"latency_ms", result.Latency.Milliseconds(),
+	)
+	return result
+}
+
+// NewPoolJob returns a Job that loads its items with load and processes them with RunPool.
+// The job fails when loading fails, the pool is cancelled, or any item fails.
+func NewPoolJob[T any](logger Logger, load func(ctx context.Context) ([]T, error), fn func(ctx context.Context, item T) error, cfg PoolConfig) *Job {
+	if load == nil {
+		panic("load cannot be nil")
+	}
+	if fn == nil {
+		panic("fn cannot be nil")
+	}
+	return NewJobContext(logger, func(ctx context.Context) error {
+		items, err := load(ctx)
+		if err != nil {
+			return fmt.Errorf("error loading items: %w", err)
+		}
+		return RunPool(ctx, logger, items, fn, cfg).Err()
+	})
+}
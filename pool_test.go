@@ -0,0 +1,118 @@
+package ezutil_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPool(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	var running, maxRunning, sum atomic.Int64
+	result := ezutil.RunPool(context.Background(), ezutil.NewTestLogger(), items, func(_ context.Context, item int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		sum.Add(int64(item))
+		if item%10 == 3 {
+			return fmt.Errorf("bad record %d", item)
+		}
+		return nil
+	}, ezutil.PoolConfig{Workers: 4, ProgressEvery: 25})
+
+	assert.LessOrEqual(t, maxRunning.Load(), int64(4))
+	assert.Equal(t, int64(4950), sum.Load())
+	assert.Equal(t, 100, result.Total)
+	assert.Equal(t, 90, result.Succeeded)
+	assert.Equal(t, 10, result.Failed)
+	assert.Zero(t, result.Skipped)
+	require.Len(t, result.Errors, 10)
+	assert.Equal(t, 3, result.Errors[0].Index)
+	assert.Equal(t, 93, result.Errors[9].Index)
+	assert.EqualError(t, result.Err(), "10 of 100 items failed, first error: item 3: bad record 3")
+}
+
+func TestRunPool_Empty(t *testing.T) {
+	result := ezutil.RunPool(context.Background(), ezutil.NewTestLogger(), []string(nil), func(context.Context, string) error {
+		return nil
+	}, ezutil.PoolConfig{})
+	assert.Zero(t, result.Total)
+	assert.NoError(t, result.Err())
+}
+
+func TestRunPool_Panic(t *testing.T) {
+	result := ezutil.RunPool(context.Background(), ezutil.NewTestLogger(), []int{1, 2}, func(_ context.Context, item int) error {
+		if item == 2 {
+			panic("nil record")
+		}
+		return nil
+	}, ezutil.PoolConfig{Workers: 2})
+
+	assert.Equal(t, 1, result.Succeeded)
+	require.Len(t, result.Errors, 1)
+	var panicErr *ezutil.PanicError
+	assert.ErrorAs(t, result.Errors[0], &panicErr)
+	assert.Equal(t, 1, result.Errors[0].Index)
+}
+
+func TestRunPool_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var processed atomic.Int64
+	result := ezutil.RunPool(ctx, ezutil.NewTestLogger(), make([]int, 50), func(context.Context, int) error {
+		if processed.Add(1) == 5 {
+			cancel()
+		}
+		return nil
+	}, ezutil.PoolConfig{Workers: 1})
+
+	assert.Equal(t, 5, result.Succeeded)
+	assert.Equal(t, 45, result.Skipped)
+	assert.ErrorIs(t, result.CtxErr, context.Canceled)
+	assert.ErrorIs(t, result.Err(), context.Canceled)
+}
+
+func TestRunPool_StopOnError(t *testing.T) {
+	errBad := errors.New("bad record")
+	result := ezutil.RunPool(context.Background(), ezutil.NewTestLogger(), make([]int, 50), func(context.Context, int) error {
+		return errBad
+	}, ezutil.PoolConfig{Workers: 1, StopOnError: true})
+
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 49, result.Skipped)
+	assert.NoError(t, result.CtxErr)
+	assert.ErrorIs(t, result.Err(), errBad)
+}
+
+func TestNewPoolJob(t *testing.T) {
+	var processed atomic.Int64
+	job := ezutil.NewPoolJob(ezutil.NewTestLogger(), func(context.Context) ([]string, error) {
+		return []string{"a", "b", "c"}, nil
+	}, func(context.Context, string) error {
+		processed.Add(1)
+		return nil
+	}, ezutil.PoolConfig{Workers: 2})
+
+	require.NoError(t, job.RunE())
+	assert.Equal(t, int64(3), processed.Load())
+
+	err := ezutil.NewPoolJob(ezutil.NewTestLogger(), func(context.Context) ([]string, error) {
+		return nil, errors.New("db down")
+	}, func(context.Context, string) error { return nil }, ezutil.PoolConfig{}).RunE()
+	assert.EqualError(t, err, "error running job: error loading items: db down")
+}
@@ -0,0 +1,68 @@
+package ezutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRotatingFile_EmptyFilename(t *testing.T) {
+	file, err := ezutil.NewRotatingFile(ezutil.RotatingFileConfig{})
+	assert.Error(t, err)
+	assert.Nil(t, file)
+}
+
+func TestRotatingFile_WithSimpleLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.log")
+	file, err := ezutil.NewRotatingFile(ezutil.RotatingFileConfig{
+		Filename:   path,
+		MaxSize:    100,
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	require.NoError(t, err)
+
+	logger := ezutil.NewSimpleLogger("JOB", false, 0, ezutil.WithLevel(ezutil.LevelInfo), ezutil.WithWriter(file), ezutil.WithJSONOutput())
+	for i := range 10 {
+		logger.Infow("processed batch", "batch", i)
+	}
+	require.NoError(t, file.Close())
+
+	backups, err := file.Backups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for _, backup := range backups {
+		assert.True(t, strings.HasSuffix(backup, ".log.gz"))
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"batch":9`)
+}
+
+func TestRotatingFile_RotateAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.log")
+	file, err := ezutil.NewRotatingFile(ezutil.RotatingFileConfig{Filename: path})
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Rotate())
+	require.NoError(t, file.Reopen())
+	_, err = file.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	backups, err := file.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(data))
+}
@@ -0,0 +1,326 @@
+package ezutil
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2/internal"
+	"github.com/itsLeonB/ungerr"
+)
+
+// Schedule computes when a scheduled job runs next.
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a five-field cron expression such as "30 2 * * MON-FRI", a descriptor
+// such as "@daily" or "@hourly", or a fixed interval written as "@every 15m".
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || d <= 0 {
+			return nil, ungerr.Unknownf("invalid interval %q in schedule %q", rest, spec)
+		}
+		return Every(d), nil
+	}
+	schedule, err := internal.ParseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Every returns a Schedule that runs every d, measured from the previous trigger.
+func Every(d time.Duration) Schedule {
+	if d <= 0 {
+		panic("interval must be positive")
+	}
+	return interval(d)
+}
+
+type interval time.Duration
+
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// OverlapPolicy decides what a Scheduler does when a job is due while its previous run is still in progress.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip drops the new run.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue starts the new run as soon as the previous one finishes, so runs follow
+	// one another rather than overlap. At most one run is queued; later ones are skipped.
+	OverlapQueue
+	// OverlapAllow starts the new run concurrently.
+	OverlapAllow
+)
+
+// ScheduleConfig controls how a Scheduler runs a job.
+type ScheduleConfig struct {
+	// Overlap decides what happens when a run is due while the previous one is in progress.
+	// Defaults to OverlapSkip.
+	Overlap OverlapPolicy
+	// Jitter delays each run by a random duration up to Jitter, spreading out jobs that share a schedule.
+	// The schedule itself is not shifted: an interval is still measured between un-jittered run times.
+	Jitter time.Duration
+	// Location is the time zone cron expressions are evaluated in. Nil means time.Local.
+	Location *time.Location
+}
+
+// Scheduler runs jobs in-process on cron expressions or fixed intervals.
+// Jobs are run with Job.Execute, so a failing job is logged and does not exit the process.
+type Scheduler struct {
+	logger Logger
+
+	mu      sync.Mutex
+	entries []*scheduledJob
+	started bool
+	stopped bool
+	stop    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	loops sync.WaitGroup
+	runs  sync.WaitGroup
+}
+
+type scheduledJob struct {
+	name     string
+	job      *Job
+	schedule Schedule
+	cfg      ScheduleConfig
+
+	mu      sync.Mutex
+	running int
+	queued  int
+}
+
+func NewScheduler(logger Logger) *Scheduler {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+	return &Scheduler{
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Add registers job to run on spec, which is parsed by ParseSchedule. name identifies the job
+// in log entries and must be unique. Jobs added after Start are scheduled immediately.
+func (s *Scheduler) Add(name, spec string, job *Job, cfg ScheduleConfig) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+	return s.AddSchedule(name, schedule, job, cfg)
+}
+
+// AddSchedule is like Add with a Schedule instead of a spec string.
+func (s *Scheduler) AddSchedule(name string, schedule Schedule, job *Job, cfg ScheduleConfig) error {
+	if job == nil {
+		return ungerr.Unknown("job cannot be nil")
+	}
+	if schedule == nil {
+		return ungerr.Unknown("schedule cannot be nil")
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ungerr.Unknown("scheduler is stopped")
+	}
+	for _, e := range s.entries {
+		if e.name == name {
+			return ungerr.Unknownf("job %q is already scheduled", name)
+		}
+	}
+	entry := &scheduledJob{name: name, job: job, schedule: schedule, cfg: cfg}
+	s.entries = append(s.entries, entry)
+	if s.started {
+		s.loops.Add(1)
+		go s.loop(entry)
+	}
+	return nil
+}
+
+// Start begins scheduling. ctx provides values to the jobs; cancelling it does not stop the
+// scheduler or in-flight jobs, use Stop or Run for that.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ungerr.Unknown("scheduler already started")
+	}
+	if s.stopped {
+		return ungerr.Unknown("scheduler is stopped")
+	}
+	s.started = true
+	s.ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for _, e := range s.entries {
+		s.loops.Add(1)
+		go s.loop(e)
+	}
+	s.logger.Infow("scheduler started", "jobs", len(s.entries))
+	return nil
+}
+
+// Stop stops scheduling new runs, drops queued ones and waits for in-flight runs to finish.
+// If ctx is done first, the contexts of the in-flight jobs are cancelled and ctx's error is returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.loops.Wait()
+	s.logger.Info("scheduler stopping, waiting for running jobs...")
+
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+	defer func() {
+		if s.cancel != nil {
+			s.cancel()
+		}
+	}()
+	select {
+	case <-done:
+		s.logger.Info("scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warnw("scheduler stopped before running jobs finished", "error", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// Run starts the scheduler and blocks until ctx is done, then stops it, waiting for
+// in-flight runs for up to shutdownTimeout. A shutdownTimeout of zero waits indefinitely.
+func (s *Scheduler) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	if err := s.Start(ctx); err != nil {
+		return err
+	}
+	<-ctx.Done()
+
+	stopCtx := context.Background()
+	if shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		stopCtx, cancel = context.WithTimeout(stopCtx, shutdownTimeout)
+		defer cancel()
+	}
+	return s.Stop(stopCtx)
+}
+
+// loop triggers e on its schedule. Each run time is computed from the previous one before
+// jitter is applied, so jitter delays individual runs without stretching the period.
+func (s *Scheduler) loop(e *scheduledJob) {
+	defer s.loops.Done()
+	last := time.Now()
+	for {
+		now := time.Now()
+		next := e.schedule.Next(last.In(e.cfg.Location))
+		if !next.IsZero() && next.Before(now) {
+			// The process was suspended or the clock jumped; resume from now rather than catching up.
+			next = e.schedule.Next(now.In(e.cfg.Location))
+		}
+		if next.IsZero() {
+			s.logger.Warnw("scheduled job has no next run", "job", e.name)
+			return
+		}
+		last = next
+		wait := next.Sub(now)
+		if e.cfg.Jitter > 0 {
+			wait += rand.N(e.cfg.Jitter)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+			s.trigger(e)
+		case <-s.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (s *Scheduler) trigger(e *scheduledJob) {
+	e.mu.Lock()
+	if e.running > 0 {
+		switch e.cfg.Overlap {
+		case OverlapSkip:
+			e.mu.Unlock()
+			s.logger.Warnw("skipping scheduled job, previous run still in progress", "job", e.name)
+			return
+		case OverlapQueue:
+			if e.queued > 0 {
+				e.mu.Unlock()
+				s.logger.Warnw("skipping scheduled job, a run is already queued", "job", e.name)
+				return
+			}
+			e.queued++
+			e.mu.Unlock()
+			s.logger.Infow("queueing scheduled job, previous run still in progress", "job", e.name)
+			return
+		}
+	}
+	e.running++
+	s.runs.Add(1)
+	e.mu.Unlock()
+
+	go s.execute(e)
+}
+
+// execute runs e, then any runs queued while it was in progress.
+func (s *Scheduler) execute(e *scheduledJob) {
+	defer s.runs.Done()
+	for {
+		s.runOnce(e)
+
+		e.mu.Lock()
+		if e.queued > 0 && !s.isStopped() {
+			e.queued--
+			e.mu.Unlock()
+			continue
+		}
+		e.queued = 0
+		e.running--
+		e.mu.Unlock()
+		return
+	}
+}
+
+func (s *Scheduler) runOnce(e *scheduledJob) {
+	s.logger.Infow("starting scheduled job", "job", e.name)
+	result := e.job.Execute(s.ctx)
+	if err := result.Err(); err != nil {
+		s.logger.Errorw("scheduled job failed", "job", e.name, "attempts", result.Attempts, "error", err)
+		return
+	}
+	if result.Skipped {
+		s.logger.Infow("skipped scheduled job, lock held by another owner", "job", e.name)
+		return
+	}
+	s.logger.Infow("finished scheduled job", "job", e.name, "latency_ms", result.Latency.Milliseconds())
+}
+
+func (s *Scheduler) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
@@ -0,0 +1,293 @@
+package ezutil_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2024, 3, 15, 10, 17, 0, 0, time.UTC)
+
+	every, err := ezutil.ParseSchedule("@every 90s")
+	require.NoError(t, err)
+	assert.Equal(t, from.Add(90*time.Second), every.Next(from))
+
+	cron, err := ezutil.ParseSchedule("0 3 * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 16, 3, 0, 0, 0, time.UTC), cron.Next(from))
+
+	for _, spec := range []string{"@every nope", "@every -1s", "* * *"} {
+		schedule, err := ezutil.ParseSchedule(spec)
+		assert.Error(t, err, spec)
+		assert.Nil(t, schedule, spec)
+	}
+}
+
+func TestEvery_NonPositive(t *testing.T) {
+	assert.Panics(t, func() { ezutil.Every(0) })
+}
+
+func TestNewScheduler_NilLogger(t *testing.T) {
+	assert.Panics(t, func() { ezutil.NewScheduler(nil) })
+}
+
+func TestScheduler_AddErrors(t *testing.T) {
+	s := ezutil.NewScheduler(ezutil.NewTestLogger())
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil })
+
+	require.NoError(t, s.Add("report", "@daily", job, ezutil.ScheduleConfig{}))
+	assert.Error(t, s.Add("report", "@hourly", job, ezutil.ScheduleConfig{}))
+	assert.Error(t, s.Add("bad", "61 * * * *", job, ezutil.ScheduleConfig{}))
+	assert.Error(t, s.Add("nil", "@daily", nil, ezutil.ScheduleConfig{}))
+	assert.Error(t, s.AddSchedule("nil-schedule", nil, job, ezutil.ScheduleConfig{}))
+
+	require.NoError(t, s.Stop(context.Background()))
+	assert.Error(t, s.Add("late", "@daily", job, ezutil.ScheduleConfig{}))
+	assert.Error(t, s.Start(context.Background()))
+}
+
+func TestScheduler_RunsJobs(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	s := ezutil.NewScheduler(logger)
+	var runs atomic.Int32
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		runs.Add(1)
+		return nil
+	})
+	require.NoError(t, s.AddSchedule("tick", ezutil.Every(5*time.Millisecond), job, ezutil.ScheduleConfig{
+		Jitter: time.Millisecond,
+	}))
+
+	require.NoError(t, s.Start(context.Background()))
+	assert.Error(t, s.Start(context.Background()))
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	stoppedAt := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stoppedAt, runs.Load())
+	logger.AssertLogged(t, ezutil.LevelInfo, "finished scheduled job")
+	logger.AssertLogged(t, ezutil.LevelInfo, "scheduler stopped")
+}
+
+// recordingSchedule records the times it is asked for the next run after.
+type recordingSchedule struct {
+	ezutil.Schedule
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (r *recordingSchedule) Next(t time.Time) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = append(r.times, t)
+	return r.Schedule.Next(t)
+}
+
+func (r *recordingSchedule) calls() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.times...)
+}
+
+func TestScheduler_JitterDoesNotStretchInterval(t *testing.T) {
+	s := ezutil.NewScheduler(ezutil.NewTestLogger())
+	schedule := &recordingSchedule{Schedule: ezutil.Every(20 * time.Millisecond)}
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil })
+	require.NoError(t, s.AddSchedule("tick", schedule, job, ezutil.ScheduleConfig{Jitter: 5 * time.Millisecond}))
+
+	require.NoError(t, s.Start(context.Background()))
+	assert.Eventually(t, func() bool { return len(schedule.calls()) >= 4 }, time.Second, time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	calls := schedule.calls()
+	for i := 1; i < len(calls); i++ {
+		assert.Equal(t, 20*time.Millisecond, calls[i].Sub(calls[i-1]))
+	}
+}
+
+func TestScheduler_LogsFailuresWithoutExiting(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	s := ezutil.NewScheduler(logger)
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return assert.AnError })
+	require.NoError(t, s.AddSchedule("failing", ezutil.Every(5*time.Millisecond), job, ezutil.ScheduleConfig{}))
+
+	require.NoError(t, s.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		return len(logger.EntriesAt(ezutil.LevelError)) > 0
+	}, time.Second, time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	entry := logger.EntriesAt(ezutil.LevelError)[0]
+	assert.Equal(t, "scheduled job failed", entry.Message)
+	name, _ := entry.Field("job")
+	assert.Equal(t, "failing", name)
+}
+
+// overlapJob blocks each run until release is closed and tracks how many runs overlap.
+type overlapJob struct {
+	release chan struct{}
+	mu      sync.Mutex
+	active  int
+	peak    int
+	runs    int
+	// blockedRuns is the number of runs started before release was closed.
+	blockedRuns int
+}
+
+func (o *overlapJob) run() error {
+	o.mu.Lock()
+	o.active++
+	o.runs++
+	o.peak = max(o.peak, o.active)
+	o.mu.Unlock()
+
+	<-o.release
+
+	o.mu.Lock()
+	o.active--
+	o.mu.Unlock()
+	return nil
+}
+
+func (o *overlapJob) stats() (runs, peak int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.runs, o.peak
+}
+
+func runOverlapScenario(t *testing.T, policy ezutil.OverlapPolicy) (*overlapJob, *ezutil.TestLogger) {
+	t.Helper()
+	logger := ezutil.NewTestLogger()
+	s := ezutil.NewScheduler(logger)
+	o := &overlapJob{release: make(chan struct{})}
+	job := ezutil.NewJob(ezutil.NewTestLogger(), o.run)
+	require.NoError(t, s.AddSchedule("slow", ezutil.Every(2*time.Millisecond), job, ezutil.ScheduleConfig{Overlap: policy}))
+
+	require.NoError(t, s.Start(context.Background()))
+	time.Sleep(30 * time.Millisecond)
+	o.mu.Lock()
+	o.blockedRuns = o.runs
+	o.mu.Unlock()
+	close(o.release)
+	require.NoError(t, s.Stop(context.Background()))
+	return o, logger
+}
+
+func TestScheduler_OverlapSkip(t *testing.T) {
+	o, logger := runOverlapScenario(t, ezutil.OverlapSkip)
+
+	_, peak := o.stats()
+	assert.Equal(t, 1, o.blockedRuns)
+	assert.Equal(t, 1, peak)
+	logger.AssertLogged(t, ezutil.LevelWarn, "skipping scheduled job")
+}
+
+func TestScheduler_OverlapQueue(t *testing.T) {
+	o, logger := runOverlapScenario(t, ezutil.OverlapQueue)
+
+	_, peak := o.stats()
+	assert.Equal(t, 1, peak)
+	logger.AssertLogged(t, ezutil.LevelInfo, "queueing scheduled job")
+}
+
+// burstSchedule fires every millisecond for a fixed number of runs and then never again.
+type burstSchedule struct {
+	remaining atomic.Int32
+}
+
+func (b *burstSchedule) Next(t time.Time) time.Time {
+	if b.remaining.Add(-1) < 0 {
+		return time.Time{}
+	}
+	return t.Add(time.Millisecond)
+}
+
+func TestScheduler_OverlapQueueKeepsOneRun(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	s := ezutil.NewScheduler(logger)
+	o := &overlapJob{release: make(chan struct{})}
+	schedule := &burstSchedule{}
+	schedule.remaining.Store(5)
+	job := ezutil.NewJob(ezutil.NewTestLogger(), o.run)
+	require.NoError(t, s.AddSchedule("slow", schedule, job, ezutil.ScheduleConfig{Overlap: ezutil.OverlapQueue}))
+
+	require.NoError(t, s.Start(context.Background()))
+	// The schedule is asked for a run after the last one only once every run was triggered.
+	assert.Eventually(t, func() bool { return schedule.remaining.Load() < 0 }, time.Second, time.Millisecond)
+	close(o.release)
+	assert.Eventually(t, func() bool {
+		runs, _ := o.stats()
+		return runs == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+
+	runs, peak := o.stats()
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, 1, peak)
+	logger.AssertLogged(t, ezutil.LevelWarn, "skipping scheduled job, a run is already queued")
+}
+
+func TestScheduler_OverlapAllow(t *testing.T) {
+	o, _ := runOverlapScenario(t, ezutil.OverlapAllow)
+
+	_, peak := o.stats()
+	assert.Greater(t, peak, 1)
+}
+
+func TestScheduler_StopTimeoutCancelsJobs(t *testing.T) {
+	logger := ezutil.NewTestLogger()
+	s := ezutil.NewScheduler(logger)
+	started := make(chan struct{})
+	var once sync.Once
+	cancelled := make(chan struct{})
+	job := ezutil.NewJobContext(ezutil.NewTestLogger(), func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	require.NoError(t, s.AddSchedule("stuck", ezutil.Every(time.Millisecond), job, ezutil.ScheduleConfig{}))
+	require.NoError(t, s.Start(context.Background()))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.Stop(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("job context was not cancelled")
+	}
+	logger.AssertLogged(t, ezutil.LevelWarn, "scheduler stopped before running jobs finished")
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := ezutil.NewScheduler(ezutil.NewTestLogger())
+	var runs atomic.Int32
+	job := ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		runs.Add(1)
+		return nil
+	})
+	require.NoError(t, s.AddSchedule("tick", ezutil.Every(2*time.Millisecond), job, ezutil.ScheduleConfig{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for runs.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+
+	assert.NoError(t, s.Run(ctx, time.Second))
+	assert.GreaterOrEqual(t, runs.Load(), int32(1))
+}
@@ -0,0 +1,131 @@
+package ezutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsLeonB/ungerr"
+)
+
+// SQLLockerConfig configures NewSQLLocker.
+type SQLLockerConfig struct {
+	// Table stores the locks. Defaults to "job_locks". See SQLLocker.CreateTable for its schema.
+	Table string
+	// NumberedPlaceholders writes $1, $2... as in PostgreSQL instead of ?.
+	NumberedPlaceholders bool
+	// Owner identifies this process in the table, followed by a random suffix per acquired lock.
+	// Defaults to the host name.
+	Owner string
+}
+
+// SQLLocker is a Locker backed by a table in a database/sql database, so replicas sharing a
+// database such as PostgreSQL, MySQL or SQLite also share locks. Expiry times are taken from
+// the clock of the process holding the lock, so replica clocks should be kept in sync and the
+// lock ttl should be well above their drift.
+type SQLLocker struct {
+	db    *sql.DB
+	table string
+	owner string
+	bind  func(query string) string
+}
+
+func NewSQLLocker(db *sql.DB, cfg SQLLockerConfig) (*SQLLocker, error) {
+	if db == nil {
+		return nil, ungerr.Unknown("db cannot be nil")
+	}
+	if cfg.Table == "" {
+		cfg.Table = "job_locks"
+	}
+	if !sqlIdentifierPattern.MatchString(cfg.Table) {
+		return nil, ungerr.Unknownf("invalid lock table name %q", cfg.Table)
+	}
+	if cfg.Owner == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			host = "unknown"
+		}
+		cfg.Owner = host
+	}
+	return &SQLLocker{db: db, table: cfg.Table, owner: cfg.Owner, bind: placeholderBinder(cfg.NumberedPlaceholders)}, nil
+}
+
+// CreateTable creates the lock table if it does not exist. Expiry is stored as Unix
+// milliseconds so that the schema works unchanged across databases.
+func (l *SQLLocker) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	name VARCHAR(255) PRIMARY KEY,
+	owner VARCHAR(255) NOT NULL,
+	expires_at BIGINT NOT NULL
+)`, l.table)
+	if _, err := l.db.ExecContext(ctx, query); err != nil {
+		return ungerr.Wrap(err, "error creating lock table")
+	}
+	return nil
+}
+
+// Acquire deletes key's row if it has expired, then inserts a new one. The primary key
+// makes the insert fail when another owner holds the lock.
+func (l *SQLLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	now := time.Now()
+	deleteExpired := l.bind(fmt.Sprintf("DELETE FROM %s WHERE name = ? AND expires_at <= ?", l.table))
+	if _, err := l.db.ExecContext(ctx, deleteExpired, key, now.UnixMilli()); err != nil {
+		return nil, ungerr.Wrap(err, "error clearing expired lock")
+	}
+
+	owner := l.owner + "/" + uuid.NewString()
+	insert := l.bind(fmt.Sprintf("INSERT INTO %s (name, owner, expires_at) VALUES (?, ?, ?)", l.table))
+	_, insertErr := l.db.ExecContext(ctx, insert, key, owner, now.Add(ttl).UnixMilli())
+	if insertErr == nil {
+		return &sqlLock{locker: l, key: key, owner: owner}, nil
+	}
+
+	// The insert error is driver specific, so check whether the row exists instead of parsing it.
+	var holder string
+	selectOwner := l.bind(fmt.Sprintf("SELECT owner FROM %s WHERE name = ?", l.table))
+	switch err := l.db.QueryRowContext(ctx, selectOwner, key).Scan(&holder); {
+	case err == nil:
+		return nil, ErrLockHeld
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ungerr.Wrap(insertErr, "error inserting lock")
+	default:
+		return nil, ungerr.Wrap(err, "error reading lock owner")
+	}
+}
+
+type sqlLock struct {
+	locker *SQLLocker
+	key    string
+	owner  string
+}
+
+func (s *sqlLock) Renew(ctx context.Context, ttl time.Duration) error {
+	l := s.locker
+	now := time.Now()
+	query := l.bind(fmt.Sprintf("UPDATE %s SET expires_at = ? WHERE name = ? AND owner = ? AND expires_at > ?", l.table))
+	res, err := l.db.ExecContext(ctx, query, now.Add(ttl).UnixMilli(), s.key, s.owner, now.UnixMilli())
+	if err != nil {
+		return ungerr.Wrap(err, "error renewing lock")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return ungerr.Wrap(err, "error renewing lock")
+	}
+	if n == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (s *sqlLock) Release(ctx context.Context) error {
+	l := s.locker
+	query := l.bind(fmt.Sprintf("DELETE FROM %s WHERE name = ? AND owner = ?", l.table))
+	if _, err := l.db.ExecContext(ctx, query, s.key, s.owner); err != nil {
+		return ungerr.Wrap(err, "error releasing lock")
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package ezutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockTable understands the statements issued by SQLLocker, keeping the locks in a map.
+type lockTable struct {
+	*fakeSQLDriver
+	tables map[string]bool
+	rows   map[string]lockRow
+}
+
+type lockRow struct {
+	owner     string
+	expiresAt int64
+}
+
+func newLockTableDB(t *testing.T) (*sql.DB, *lockTable) {
+	d := &lockTable{tables: make(map[string]bool), rows: make(map[string]lockRow)}
+	d.fakeSQLDriver = &fakeSQLDriver{exec: d.exec, query: d.query}
+	return openFakeDB(t, d.fakeSQLDriver), d
+}
+
+func (d *lockTable) exec(query string, args []driver.Value) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS"):
+		d.tables[strings.Fields(query)[5]] = true
+		return driver.RowsAffected(0), nil
+	case strings.Contains(query, "AND expires_at <="):
+		key, now := args[0].(string), args[1].(int64)
+		if row, ok := d.rows[key]; ok && row.expiresAt <= now {
+			delete(d.rows, key)
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(query, "INSERT"):
+		key := args[0].(string)
+		if _, ok := d.rows[key]; ok {
+			return nil, errors.New("UNIQUE constraint failed: job_locks.name")
+		}
+		d.rows[key] = lockRow{owner: args[1].(string), expiresAt: args[2].(int64)}
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "UPDATE"):
+		expiresAt, key, owner, now := args[0].(int64), args[1].(string), args[2].(string), args[3].(int64)
+		row, ok := d.rows[key]
+		if !ok || row.owner != owner || row.expiresAt <= now {
+			return driver.RowsAffected(0), nil
+		}
+		d.rows[key] = lockRow{owner: owner, expiresAt: expiresAt}
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "DELETE"):
+		key, owner := args[0].(string), args[1].(string)
+		if row, ok := d.rows[key]; ok && row.owner == owner {
+			delete(d.rows, key)
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	}
+	return nil, errors.New("unexpected statement: " + query)
+}
+
+func (d *lockTable) query(_ string, args []driver.Value) (driver.Rows, error) {
+	rows := &fakeSQLRows{columns: []string{"owner"}}
+	if row, ok := d.rows[args[0].(string)]; ok {
+		rows.rows = [][]driver.Value{{row.owner}}
+	}
+	return rows, nil
+}
+
+func TestNewSQLLocker_Validation(t *testing.T) {
+	_, err := ezutil.NewSQLLocker(nil, ezutil.SQLLockerConfig{})
+	assert.Error(t, err)
+
+	db, _ := newLockTableDB(t)
+	_, err = ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{Table: "locks; DROP TABLE users"})
+	assert.Error(t, err)
+
+	_, err = ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{Table: "ops.job_locks"})
+	assert.NoError(t, err)
+}
+
+func TestSQLLocker(t *testing.T) {
+	ctx := context.Background()
+	db, d := newLockTableDB(t)
+	replicaA, err := ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{Owner: "pod-a"})
+	require.NoError(t, err)
+	replicaB, err := ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{Owner: "pod-b"})
+	require.NoError(t, err)
+
+	require.NoError(t, replicaA.CreateTable(ctx))
+	assert.True(t, d.tables["job_locks"])
+
+	lock, err := replicaA.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(d.rows["report"].owner, "pod-a/"))
+
+	_, err = replicaB.Acquire(ctx, "report", time.Minute)
+	assert.ErrorIs(t, err, ezutil.ErrLockHeld)
+
+	require.NoError(t, lock.Renew(ctx, time.Minute))
+	require.NoError(t, lock.Release(ctx))
+	assert.ErrorIs(t, lock.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+
+	lockB, err := replicaB.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lockB.Release(ctx))
+	assert.Empty(t, d.rows)
+}
+
+func TestSQLLocker_Expiry(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newLockTableDB(t)
+	locker, err := ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{})
+	require.NoError(t, err)
+
+	stale, err := locker.Acquire(ctx, "report", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	fresh, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	assert.ErrorIs(t, stale.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+
+	require.NoError(t, stale.Release(ctx))
+	_, err = locker.Acquire(ctx, "report", time.Minute)
+	assert.ErrorIs(t, err, ezutil.ErrLockHeld)
+	require.NoError(t, fresh.Release(ctx))
+}
+
+func TestSQLLocker_NumberedPlaceholders(t *testing.T) {
+	ctx := context.Background()
+	db, d := newLockTableDB(t)
+	locker, err := ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{Table: "locks", NumberedPlaceholders: true})
+	require.NoError(t, err)
+
+	lock, err := locker.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(ctx))
+
+	assert.Equal(t, []string{
+		"DELETE FROM locks WHERE name = $1 AND expires_at <= $2",
+		"INSERT INTO locks (name, owner, expires_at) VALUES ($1, $2, $3)",
+		"DELETE FROM locks WHERE name = $1 AND owner = $2",
+	}, d.executed())
+}
+
+func TestJob_WithSQLLocker(t *testing.T) {
+	db, d := newLockTableDB(t)
+	locker, err := ezutil.NewSQLLocker(db, ezutil.SQLLockerConfig{})
+	require.NoError(t, err)
+
+	var heldDuringRun bool
+	err = ezutil.NewJob(ezutil.NewTestLogger(), func() error {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		_, heldDuringRun = d.rows["migrate"]
+		return nil
+	}).WithLock(locker, "migrate", time.Minute).RunE()
+
+	assert.NoError(t, err)
+	assert.True(t, heldDuringRun)
+	assert.Empty(t, d.rows)
+}
+
+func TestSQLLocker_Integration(t *testing.T) {
+	ctx := context.Background()
+	db, numbered := openIntegrationDB(t, "ezutil_test_job_locks")
+	cfg := ezutil.SQLLockerConfig{Table: "ezutil_test_job_locks", NumberedPlaceholders: numbered}
+	cfg.Owner = "pod-a"
+	replicaA, err := ezutil.NewSQLLocker(db, cfg)
+	require.NoError(t, err)
+	cfg.Owner = "pod-b"
+	replicaB, err := ezutil.NewSQLLocker(db, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, replicaA.CreateTable(ctx))
+	require.NoError(t, replicaA.CreateTable(ctx), "CreateTable is idempotent")
+
+	lock, err := replicaA.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	_, err = replicaB.Acquire(ctx, "report", time.Minute)
+	assert.ErrorIs(t, err, ezutil.ErrLockHeld)
+
+	require.NoError(t, lock.Renew(ctx, time.Minute))
+	require.NoError(t, lock.Release(ctx))
+	assert.ErrorIs(t, lock.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+
+	stale, err := replicaB.Acquire(ctx, "report", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	fresh, err := replicaA.Acquire(ctx, "report", time.Minute)
+	require.NoError(t, err)
+	assert.ErrorIs(t, stale.Renew(ctx, time.Minute), ezutil.ErrLockLost)
+	require.NoError(t, fresh.Release(ctx))
+}
@@ -0,0 +1,134 @@
+package ezutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/itsLeonB/ungerr"
+)
+
+// SQLRunRecorderConfig configures NewSQLRunRecorder.
+type SQLRunRecorderConfig struct {
+	// Table stores the runs. Defaults to "job_runs". See SQLRunRecorder.CreateTable for its schema.
+	Table string
+	// NumberedPlaceholders writes $1, $2... as in PostgreSQL instead of ?.
+	NumberedPlaceholders bool
+}
+
+// SQLRunRecorder stores job runs in a database/sql table. It implements RunRecorder and RunHistory.
+type SQLRunRecorder struct {
+	db    *sql.DB
+	table string
+	bind  func(query string) string
+}
+
+func NewSQLRunRecorder(db *sql.DB, cfg SQLRunRecorderConfig) (*SQLRunRecorder, error) {
+	if db == nil {
+		return nil, ungerr.Unknown("db cannot be nil")
+	}
+	if cfg.Table == "" {
+		cfg.Table = "job_runs"
+	}
+	if !sqlIdentifierPattern.MatchString(cfg.Table) {
+		return nil, ungerr.Unknownf("invalid run table name %q", cfg.Table)
+	}
+	return &SQLRunRecorder{db: db, table: cfg.Table, bind: placeholderBinder(cfg.NumberedPlaceholders)}, nil
+}
+
+// CreateTable creates the run table if it does not exist. Times are stored as Unix
+// milliseconds so that the schema works unchanged across databases; finished_at is 0
+// while a run is in progress.
+func (r *SQLRunRecorder) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id VARCHAR(36) PRIMARY KEY,
+	job VARCHAR(255) NOT NULL,
+	status VARCHAR(16) NOT NULL,
+	started_at BIGINT NOT NULL,
+	finished_at BIGINT NOT NULL,
+	error TEXT NOT NULL,
+	attempts INTEGER NOT NULL,
+	latency_ms BIGINT NOT NULL
+)`, r.table)
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return ungerr.Wrap(err, "error creating run table")
+	}
+	return nil
+}
+
+func (r *SQLRunRecorder) Start(ctx context.Context, run RunRecord) error {
+	query := r.bind(fmt.Sprintf(`INSERT INTO %s (id, job, status, started_at, finished_at, error, attempts, latency_ms)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, r.table))
+	_, err := r.db.ExecContext(ctx, query,
+		run.ID, run.Job, string(run.Status), unixMilli(run.StartedAt), unixMilli(run.FinishedAt),
+		run.Error, run.Attempts, run.Latency.Milliseconds())
+	if err != nil {
+		return ungerr.Wrap(err, "error inserting job run")
+	}
+	return nil
+}
+
+func (r *SQLRunRecorder) Finish(ctx context.Context, run RunRecord) error {
+	query := r.bind(fmt.Sprintf(`UPDATE %s SET status = ?, finished_at = ?, error = ?, attempts = ?, latency_ms = ?
+WHERE id = ?`, r.table))
+	_, err := r.db.ExecContext(ctx, query,
+		string(run.Status), unixMilli(run.FinishedAt), run.Error, run.Attempts, run.Latency.Milliseconds(), run.ID)
+	if err != nil {
+		return ungerr.Wrap(err, "error updating job run")
+	}
+	return nil
+}
+
+func (r *SQLRunRecorder) Runs(ctx context.Context, job string, limit int) ([]RunRecord, error) {
+	query := fmt.Sprintf(`SELECT id, job, status, started_at, finished_at, error, attempts, latency_ms
+FROM %s WHERE job = ? ORDER BY started_at DESC`, r.table)
+	args := []any{job}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.bind(query), args...)
+	if err != nil {
+		return nil, ungerr.Wrap(err, "error querying job runs")
+	}
+	defer func() { _ = rows.Close() }()
+
+	var runs []RunRecord
+	for rows.Next() {
+		var (
+			run                   RunRecord
+			status                string
+			startedAt, finishedAt int64
+			latencyMs             int64
+		)
+		if err := rows.Scan(&run.ID, &run.Job, &status, &startedAt, &finishedAt, &run.Error, &run.Attempts, &latencyMs); err != nil {
+			return nil, ungerr.Wrap(err, "error scanning job run")
+		}
+		run.Status = RunStatus(status)
+		run.StartedAt = fromUnixMilli(startedAt)
+		run.FinishedAt = fromUnixMilli(finishedAt)
+		run.Latency = time.Duration(latencyMs) * time.Millisecond
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ungerr.Wrap(err, "error reading job runs")
+	}
+	return runs, nil
+}
+
+// unixMilli is t in Unix milliseconds, with the zero time stored as 0.
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
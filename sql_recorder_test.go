@@ -0,0 +1,178 @@
+package ezutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runTable understands the statements issued by SQLRunRecorder, keeping the runs in a slice.
+type runTable struct {
+	*fakeSQLDriver
+	created bool
+	rows    [][]driver.Value
+}
+
+func newRunTableDB(t *testing.T) (*sql.DB, *runTable) {
+	d := &runTable{}
+	d.fakeSQLDriver = &fakeSQLDriver{exec: d.exec, query: d.query}
+	return openFakeDB(t, d.fakeSQLDriver), d
+}
+
+func (d *runTable) exec(query string, args []driver.Value) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS"):
+		d.created = true
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(query, "INSERT"):
+		d.rows = append(d.rows, slices.Clone(args))
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "UPDATE"):
+		for _, row := range d.rows {
+			if row[0] == args[5] {
+				row[2], row[4], row[5], row[6], row[7] = args[0], args[1], args[2], args[3], args[4]
+				return driver.RowsAffected(1), nil
+			}
+		}
+		return driver.RowsAffected(0), nil
+	}
+	return nil, errors.New("unexpected statement: " + query)
+}
+
+func (d *runTable) query(_ string, args []driver.Value) (driver.Rows, error) {
+	var matched [][]driver.Value
+	for _, row := range d.rows {
+		if row[1] == args[0] {
+			matched = append(matched, slices.Clone(row))
+		}
+	}
+	slices.SortFunc(matched, func(a, b []driver.Value) int {
+		return int(b[3].(int64) - a[3].(int64))
+	})
+	if len(args) > 1 {
+		matched = matched[:min(len(matched), int(args[1].(int64)))]
+	}
+	return &fakeSQLRows{
+		columns: []string{"id", "job", "status", "started_at", "finished_at", "error", "attempts", "latency_ms"},
+		rows:    matched,
+	}, nil
+}
+
+func TestNewSQLRunRecorder_Validation(t *testing.T) {
+	_, err := ezutil.NewSQLRunRecorder(nil, ezutil.SQLRunRecorderConfig{})
+	assert.Error(t, err)
+
+	db, _ := newRunTableDB(t)
+	_, err = ezutil.NewSQLRunRecorder(db, ezutil.SQLRunRecorderConfig{Table: "runs--"})
+	assert.Error(t, err)
+}
+
+func TestSQLRunRecorder(t *testing.T) {
+	ctx := context.Background()
+	db, d := newRunTableDB(t)
+	recorder, err := ezutil.NewSQLRunRecorder(db, ezutil.SQLRunRecorderConfig{})
+	require.NoError(t, err)
+	require.NoError(t, recorder.CreateTable(ctx))
+	assert.True(t, d.created)
+
+	first := time.UnixMilli(time.Date(2024, 3, 15, 2, 0, 0, 0, time.UTC).UnixMilli())
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "a", Job: "report", Status: ezutil.RunRunning, StartedAt: first}))
+	require.NoError(t, recorder.Finish(ctx, ezutil.RunRecord{
+		ID: "a", Job: "report", Status: ezutil.RunFailed, StartedAt: first, FinishedAt: first.Add(3 * time.Second),
+		Error: "error running job: timeout", Attempts: 3, Latency: 2500 * time.Millisecond,
+	}))
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "b", Job: "report", Status: ezutil.RunRunning, StartedAt: first.Add(time.Hour)}))
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "c", Job: "other", Status: ezutil.RunRunning, StartedAt: first}))
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	assert.Equal(t, "b", runs[0].ID)
+	assert.Equal(t, ezutil.RunRunning, runs[0].Status)
+	assert.True(t, runs[0].FinishedAt.IsZero())
+
+	assert.Equal(t, ezutil.RunRecord{
+		ID: "a", Job: "report", Status: ezutil.RunFailed, StartedAt: first, FinishedAt: first.Add(3 * time.Second),
+		Error: "error running job: timeout", Attempts: 3, Latency: 2500 * time.Millisecond,
+	}, runs[1])
+
+	latest, err := recorder.Runs(ctx, "report", 1)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, "b", latest[0].ID)
+}
+
+func TestSQLRunRecorder_NumberedPlaceholders(t *testing.T) {
+	ctx := context.Background()
+	db, d := newRunTableDB(t)
+	recorder, err := ezutil.NewSQLRunRecorder(db, ezutil.SQLRunRecorderConfig{Table: "ops.runs", NumberedPlaceholders: true})
+	require.NoError(t, err)
+
+	_, err = recorder.Runs(ctx, "report", 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"SELECT id, job, status, started_at, finished_at, error, attempts, latency_ms\n" +
+			"FROM ops.runs WHERE job = $1 ORDER BY started_at DESC LIMIT $2",
+	}, d.executed())
+}
+
+func TestJob_WithSQLRunRecorder(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newRunTableDB(t)
+	recorder, err := ezutil.NewSQLRunRecorder(db, ezutil.SQLRunRecorderConfig{})
+	require.NoError(t, err)
+
+	result := ezutil.NewJob(ezutil.NewTestLogger(), func() error { return nil }).
+		WithRecorder(recorder, "report").
+		Execute(ctx)
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, result.RunID, runs[0].ID)
+	assert.Equal(t, ezutil.RunSucceeded, runs[0].Status)
+	assert.Equal(t, 1, runs[0].Attempts)
+}
+
+func TestSQLRunRecorder_Integration(t *testing.T) {
+	ctx := context.Background()
+	db, numbered := openIntegrationDB(t, "ezutil_test_job_runs")
+	recorder, err := ezutil.NewSQLRunRecorder(db, ezutil.SQLRunRecorderConfig{Table: "ezutil_test_job_runs", NumberedPlaceholders: numbered})
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.CreateTable(ctx))
+	require.NoError(t, recorder.CreateTable(ctx), "CreateTable is idempotent")
+
+	first := time.UnixMilli(time.Date(2024, 3, 15, 2, 0, 0, 0, time.UTC).UnixMilli())
+	failed := ezutil.RunRecord{
+		ID: "a", Job: "report", Status: ezutil.RunFailed, StartedAt: first, FinishedAt: first.Add(3 * time.Second),
+		Error: "error running job: timeout", Attempts: 3, Latency: 2500 * time.Millisecond,
+	}
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "a", Job: "report", Status: ezutil.RunRunning, StartedAt: first}))
+	require.NoError(t, recorder.Finish(ctx, failed))
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "b", Job: "report", Status: ezutil.RunRunning, StartedAt: first.Add(time.Hour)}))
+	require.NoError(t, recorder.Start(ctx, ezutil.RunRecord{ID: "c", Job: "other", Status: ezutil.RunRunning, StartedAt: first}))
+
+	runs, err := recorder.Runs(ctx, "report", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "b", runs[0].ID)
+	assert.True(t, runs[0].FinishedAt.IsZero())
+	assert.Equal(t, failed, runs[1])
+
+	latest, err := recorder.Runs(ctx, "report", 1)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, "b", latest[0].ID)
+}
@@ -2,6 +2,9 @@ package ezutil
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,3 +26,28 @@ func GetTimeRangeClause(timeCol string, start, end time.Time) (string, []any) {
 
 	return fmt.Sprintf("%s BETWEEN ? AND ?", timeCol), []any{start, end}
 }
+
+// sqlIdentifierPattern matches table names, optionally schema-qualified, that are safe to
+// format into a query.
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// placeholderBinder returns a function that rewrites the ? placeholders of a query as
+// $1, $2 and so on when numbered is set, and leaves the query unchanged otherwise.
+func placeholderBinder(numbered bool) func(query string) string {
+	if !numbered {
+		return func(query string) string { return query }
+	}
+	return func(query string) string {
+		var b strings.Builder
+		n := 0
+		for _, r := range query {
+			if r == '?' {
+				n++
+				b.WriteString("$" + strconv.Itoa(n))
+				continue
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+}
@@ -1,13 +1,116 @@
 package ezutil_test
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/itsLeonB/ezutil/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// fakeSQLDriver is a database/sql driver that hands every statement to exec or query,
+// standing in for a real database in tests. Statements are recorded in order, and the
+// handlers run with mu held.
+type fakeSQLDriver struct {
+	mu      sync.Mutex
+	exec    func(query string, args []driver.Value) (driver.Result, error)
+	query   func(query string, args []driver.Value) (driver.Rows, error)
+	queries []string
+}
+
+func openFakeDB(t *testing.T, d *fakeSQLDriver) *sql.DB {
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func (d *fakeSQLDriver) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *fakeSQLDriver) Driver() driver.Driver                        { return nil }
+func (d *fakeSQLDriver) Close() error                                 { return nil }
+
+func (d *fakeSQLDriver) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{d: d, query: query}, nil
+}
+
+func (d *fakeSQLDriver) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (d *fakeSQLDriver) executed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+type fakeSQLStmt struct {
+	d     *fakeSQLDriver
+	query string
+}
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	return s.d.exec(s.query, args)
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	return s.d.query(s.query, args)
+}
+
+type fakeSQLRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return r.columns }
+func (r *fakeSQLRows) Close() error      { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// openIntegrationDB opens the database named by the EZUTIL_TEST_SQL_DRIVER and
+// EZUTIL_TEST_SQL_DSN environment variables, skipping the test when they are unset, and
+// drops table once the test ends. The module depends on no database driver, so one has to
+// be linked into the test binary first, for example through an untracked driver_test.go
+// holding `import _ "github.com/jackc/pgx/v5/stdlib"`. Set EZUTIL_TEST_SQL_NUMBERED=1 for
+// databases with $1 placeholders such as PostgreSQL.
+func openIntegrationDB(t *testing.T, table string) (db *sql.DB, numbered bool) {
+	driverName, dsn := os.Getenv("EZUTIL_TEST_SQL_DRIVER"), os.Getenv("EZUTIL_TEST_SQL_DSN")
+	if driverName == "" || dsn == "" {
+		t.Skip("EZUTIL_TEST_SQL_DRIVER and EZUTIL_TEST_SQL_DSN are not set")
+	}
+	db, err := sql.Open(driverName, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.Ping())
+
+	_, err = db.Exec("DROP TABLE IF EXISTS " + table)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = db.Exec("DROP TABLE IF EXISTS " + table) })
+	return db, os.Getenv("EZUTIL_TEST_SQL_NUMBERED") == "1"
+}
+
 func TestGetTimeRangeClause(t *testing.T) {
 	timeCol := "created_at"
 	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)